@@ -2,12 +2,11 @@ package addon_providers
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context) error {
@@ -20,13 +19,12 @@ func List(ctx context.Context) error {
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(addonProviders)
 	t.SetHeader([]string{"ID", "Name"})
 
 	for _, addon := range addonProviders {
 		t.Append([]string{addon.ID, addon.Name})
 	}
 
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -2,12 +2,11 @@ package addon_providers
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func Plans(ctx context.Context, addon string) error {
@@ -21,11 +20,10 @@ func Plans(ctx context.Context, addon string) error {
 		return errgo.Mask(err, errgo.Any)
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(plans)
 	t.SetHeader([]string{"ID", "Name"})
 	for _, plan := range plans {
 		t.Append([]string{plan.Name, plan.DisplayName})
 	}
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -6,28 +6,33 @@ package addons
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
 )
 
+type addonInfo struct {
+	Addon    scalingo.Addon    `json:"addon"`
+	Database scalingo.Database `json:"database"`
+}
+
 // Info is the command handler displaying static information about one given addon
-func Info(ctx context.Context, app, addon string) error {
+func Info(ctx context.Context, app, addonID string) error {
 	c, err := config.ScalingoClient(ctx)
 	if err != nil {
 		return errgo.Notef(err, "fail to get Scalingo client")
 	}
 
-	addonInfo, err := c.AddonShow(ctx, app, addon)
+	addon, err := c.AddonShow(ctx, app, addonID)
 	if err != nil {
 		return errgo.Notef(err, "fail to get addon information")
 	}
 
-	dbInfo, err := c.DatabaseShow(ctx, app, addon)
+	dbInfo, err := c.DatabaseShow(ctx, app, addonID)
 	if err != nil {
 		return errgo.Notef(err, "fail to get database information")
 	}
@@ -41,15 +46,13 @@ func Info(ctx context.Context, app, addon string) error {
 		}
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(addonInfo{Addon: addon, Database: dbInfo})
 	t.Append([]string{"Database Type", fmt.Sprintf("%v", dbInfo.TypeName)})
 	t.Append([]string{"Version", fmt.Sprintf("%v", dbInfo.ReadableVersion)})
-	t.Append([]string{"Status", fmt.Sprintf("%v", addonInfo.Status)})
-	t.Append([]string{"Plan", fmt.Sprintf("%v", addonInfo.Plan.Name)})
+	t.Append([]string{"Status", fmt.Sprintf("%v", addon.Status)})
+	t.Append([]string{"Plan", fmt.Sprintf("%v", addon.Plan.Name)})
 	t.Append([]string{"Force TLS", forceSsl})
 	t.Append([]string{"Internet Accessibility", internetAccess})
 
-	t.Render()
-
-	return nil
+	return t.Render()
 }
@@ -2,12 +2,11 @@ package addons
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context, app string) error {
@@ -20,13 +19,11 @@ func List(ctx context.Context, app string) error {
 		return errgo.Mask(err, errgo.Any)
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(resources)
 	t.SetHeader([]string{"Addon", "ID", "Plan", "Status"})
 
 	for _, resource := range resources {
 		t.Append([]string{resource.AddonProvider.Name, resource.ID, resource.Plan.Name, string(resource.Status)})
 	}
-	t.Render()
-
-	return nil
+	return t.Render()
 }
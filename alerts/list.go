@@ -3,12 +3,11 @@ package alerts
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context, app string) error {
@@ -22,7 +21,7 @@ func List(ctx context.Context, app string) error {
 		return errgo.Mask(err)
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(alerts)
 	headers := []string{"ID", "Active", "Container Type", "Metric", "Limit"}
 	hasRemindEvery := false
 	for _, alert := range alerts {
@@ -59,6 +58,5 @@ func List(ctx context.Context, app string) error {
 		}
 		t.Append(row)
 	}
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -0,0 +1,113 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// ContainerSizes is the command handler listing the container sizes available
+// in the region
+func ContainerSizes(ctx context.Context) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	sizes, err := c.ContainerSizesList(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the container sizes")
+	}
+
+	t := output.NewTable(sizes)
+	t.SetHeader([]string{"Name", "Memory", "CPU", "Hourly Price", "Monthly Price"})
+	for _, size := range sizes {
+		t.Append([]string{
+			size.Name,
+			humanize.IBytes(uint64(size.Memory)),
+			size.HumanCPU,
+			utils.FormatPrice(size.HourlyPrice),
+			utils.FormatPrice(size.ThirtydaysPrice),
+		})
+	}
+	return t.Render()
+}
+
+// containerSizesCatalogue returns the container sizes of the region. It
+// returns nil if the catalogue cannot be fetched: the size validation is then
+// skipped, the API has the final word anyway.
+func containerSizesCatalogue(ctx context.Context, c scalingo.ContainerSizesService) []scalingo.ContainerSize {
+	sizes, err := c.ContainerSizesList(ctx)
+	if err != nil {
+		debug.Println("fail to list the container sizes, skipping the size validation:", err)
+		return nil
+	}
+	return sizes
+}
+
+// checkContainerSize checks the size against the catalogue before sending it
+// to the API. The size name is returned with the case expected by the API.
+func checkContainerSize(sizes []scalingo.ContainerSize, size string) (string, error) {
+	if len(sizes) == 0 {
+		return size, nil
+	}
+
+	names := make([]string, 0, len(sizes))
+	closest := ""
+	closestDistance := -1
+	for _, s := range sizes {
+		if strings.EqualFold(s.Name, size) {
+			return s.Name, nil
+		}
+		names = append(names, s.Name)
+
+		distance := levenshtein(normalizeContainerSize(size), strings.ToUpper(s.Name))
+		if closestDistance == -1 || distance < closestDistance {
+			closest, closestDistance = s.Name, distance
+		}
+	}
+
+	return "", errgo.Newf("unknown container size '%s', did you mean '%s'? Available sizes are: %s", size, closest, strings.Join(names, ", "))
+}
+
+// normalizeContainerSize upper cases the size and converts the XXL notation to
+// the 2XL one used by the API
+func normalizeContainerSize(size string) string {
+	size = strings.ToUpper(size)
+	xCount := len(size) - len(strings.TrimLeft(size, "X"))
+	if xCount >= 2 && size[xCount:] == "L" {
+		return fmt.Sprintf("%dXL", xCount)
+	}
+	return size
+}
+
+// levenshtein computes the edit distance between a and b
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,59 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestCheckContainerSize(t *testing.T) {
+	sizes := []scalingo.ContainerSize{{Name: "S"}, {Name: "M"}, {Name: "L"}, {Name: "XL"}, {Name: "2XL"}}
+
+	tests := map[string]struct {
+		sizes        []scalingo.ContainerSize
+		size         string
+		expectedSize string
+		expectedErr  string
+	}{
+		"it should accept a known size": {
+			sizes:        sizes,
+			size:         "XL",
+			expectedSize: "XL",
+		},
+		"it should accept a known size whatever the case": {
+			sizes:        sizes,
+			size:         "2xl",
+			expectedSize: "2XL",
+		},
+		"it should suggest the closest size": {
+			sizes:       sizes,
+			size:        "XLL",
+			expectedErr: "unknown container size 'XLL', did you mean 'XL'? Available sizes are: S, M, L, XL, 2XL",
+		},
+		"it should understand the XXL notation": {
+			sizes:       sizes,
+			size:        "XXL",
+			expectedErr: "unknown container size 'XXL', did you mean '2XL'? Available sizes are: S, M, L, XL, 2XL",
+		},
+		"it should not validate anything without catalogue": {
+			size:         "Q",
+			expectedSize: "Q",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			size, err := checkContainerSize(test.sizes, test.size)
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, test.expectedErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSize, size)
+		})
+	}
+}
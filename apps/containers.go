@@ -3,12 +3,11 @@ package apps
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func ContainerTypes(ctx context.Context, app string) error {
@@ -22,7 +21,8 @@ func ContainerTypes(ctx context.Context, app string) error {
 		return errgo.Notef(err, "fail to list the application container types")
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(containerTypes)
+	t.SetWriter(output.Writer(ctx))
 	t.SetHeader([]string{"Name", "Amount", "Size", "Command"})
 
 	hasAutoscaler := false
@@ -50,10 +50,13 @@ func ContainerTypes(ctx context.Context, app string) error {
 		}
 	}
 
-	t.Render()
+	err = t.Render()
+	if err != nil {
+		return errgo.Mask(err)
+	}
 
-	if hasAutoscaler {
-		fmt.Println("  (*) has an autoscaler defined")
+	if hasAutoscaler && output.IsHuman() {
+		fmt.Fprintln(output.Writer(ctx), "  (*) has an autoscaler defined")
 	}
 
 	return nil
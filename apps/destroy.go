@@ -14,8 +14,6 @@ import (
 )
 
 func Destroy(ctx context.Context, appName string, force bool) error {
-	var validationName string
-
 	c, err := config.ScalingoClient(ctx)
 	if err != nil {
 		return errgo.Notef(err, "fail to get Scalingo client")
@@ -28,20 +26,13 @@ func Destroy(ctx context.Context, appName string, force bool) error {
 
 	if !force {
 		fmt.Printf("/!\\ You're going to delete %s, this operation is irreversible.\nTo confirm type the name of the application: ", appName)
-		validationName, err = bufio.NewReader(os.Stdin).ReadString('\n')
+		err = confirmValidation(appName)
 		if err != nil {
 			return errgo.Mask(err, errgo.Any)
 		}
-		validationName = strings.Trim(validationName, "\n")
-
-		if validationName != appName {
-			return errgo.Newf("'%s' is not '%s', aborting…\n", validationName, appName)
-		}
-	} else {
-		validationName = appName
 	}
 
-	err = c.AppsDestroy(ctx, appName, validationName)
+	err = c.AppsDestroy(ctx, appName, appName)
 	if err != nil {
 		return errgo.Notef(err, "fail to destroy app")
 	}
@@ -49,3 +40,18 @@ func Destroy(ctx context.Context, appName string, force bool) error {
 	io.Status("App " + appName + " has been deleted")
 	return nil
 }
+
+// confirmValidation reads the validation typed by the user and returns an
+// error if it is not the expected one
+func confirmValidation(expected string) error {
+	validation, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+	validation = strings.Trim(validation, "\n")
+
+	if validation != expected {
+		return errgo.Newf("'%s' is not '%s', aborting…\n", validation, expected)
+	}
+	return nil
+}
@@ -3,13 +3,12 @@ package apps
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 	"github.com/Scalingo/go-scalingo/v6/debug"
 )
@@ -31,15 +30,13 @@ func Info(ctx context.Context, appName string) error {
 		stackName = app.StackID
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(app)
 	t.SetHeader([]string{"Settings", "Value"})
 	t.Append([]string{"Force HTTPS", fmt.Sprintf("%v", app.ForceHTTPS)})
 	t.Append([]string{"Sticky Session", fmt.Sprintf("%v", app.StickySession)})
 	t.Append([]string{"Stack", stackName})
 	t.Append([]string{"Status", fmt.Sprintf("%v", app.Status)})
-	t.Render()
-
-	return nil
+	return t.Render()
 }
 
 func getStackName(ctx context.Context, c *scalingo.Client, stackID string) (string, error) {
@@ -3,13 +3,12 @@ package apps
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context) error {
@@ -23,12 +22,12 @@ func List(ctx context.Context) error {
 		return errgo.Mask(err, errgo.Any)
 	}
 
-	if len(apps) == 0 {
+	if len(apps) == 0 && output.IsHuman() {
 		fmt.Println(io.Indent("\nYou haven't created any app yet, create your first application using:\n→ scalingo create <app_name>\n", 2))
 		return nil
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(apps)
 	t.SetHeader([]string{"Name", "Role", "Status"})
 
 	currentUser, err := config.C.CurrentUser()
@@ -43,7 +42,5 @@ func List(ctx context.Context) error {
 			t.Append([]string{app.Name, "collaborator", string(app.Status)})
 		}
 	}
-	t.Render()
-
-	return nil
+	return t.Render()
 }
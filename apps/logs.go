@@ -26,49 +26,95 @@ type LogsRes struct {
 	App     *scalingo.App `json:"app"`
 }
 
-func Logs(ctx context.Context, appName string, stream bool, n int, filter string) error {
+type LogsOpts struct {
+	Follow  bool
+	Count   int
+	Filter  string
+	Display logs.DisplayOpts
+	// TimeRange replaces Count to get the logs of a time range, from the logs
+	// archives and the recent logs
+	TimeRange logs.TimeRange
+}
+
+func Logs(ctx context.Context, appName string, opts LogsOpts) error {
 	c, err := config.ScalingoClient(ctx)
 	if err != nil {
 		return errgo.Notef(err, "fail to get Scalingo client")
 	}
 
-	err = checkFilter(ctx, c, appName, filter)
+	err = checkFilter(ctx, c, appName, opts.Filter)
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
 
-	res, err := c.LogsURL(ctx, appName)
+	logsURL, err := getLogsURL(ctx, c, appName)
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+
+	if !opts.TimeRange.IsZero() {
+		err = logs.DumpTimeRange(ctx, logsURL, opts.Filter, opts.TimeRange, logs.AppArchivesPager(c, appName), opts.Display)
+	} else {
+		err = logs.Dump(ctx, logsURL, opts.Count, opts.Filter, opts.Display)
+	}
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
+
+	if opts.Follow {
+		if err = logs.Stream(ctx, logsURL, opts.Filter, opts.Display); err != nil {
+			return errgo.Mask(err, errgo.Any)
+		}
+	}
+	return nil
+}
+
+// LogsSources returns the logs sources of the apps, to merge them with
+// logs.Sources
+func LogsSources(ctx context.Context, appNames []string, filter string) ([]logs.Source, error) {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	sources := make([]logs.Source, 0, len(appNames))
+	for _, appName := range appNames {
+		err = checkFilter(ctx, c, appName, filter)
+		if err != nil {
+			return nil, errgo.Notef(err, "invalid filter for %s", appName)
+		}
+		logsURL, err := getLogsURL(ctx, c, appName)
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to get the logs URL of %s", appName)
+		}
+		sources = append(sources, logs.Source{Name: appName, LogsURL: logsURL, Filter: filter})
+	}
+	return sources, nil
+}
+
+func getLogsURL(ctx context.Context, c *scalingo.Client, appName string) (string, error) {
+	res, err := c.LogsURL(ctx, appName)
+	if err != nil {
+		return "", errgo.Mask(err, errgo.Any)
+	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return errgo.Newf("fail to query logs: %s", res.Status)
+		return "", errgo.Newf("fail to query logs: %s", res.Status)
 	}
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return errgo.Mask(err, errgo.Any)
+		return "", errgo.Mask(err, errgo.Any)
 	}
 
 	debug.Println("[API-Response] ", string(body))
 
 	logsRes := &LogsRes{}
 	if err = json.Unmarshal(body, &logsRes); err != nil {
-		return errgo.Mask(err, errgo.Any)
+		return "", errgo.Mask(err, errgo.Any)
 	}
-
-	if err = logs.Dump(ctx, logsRes.LogsURL, n, filter); err != nil {
-		return errgo.Mask(err, errgo.Any)
-	}
-
-	if stream {
-		if err = logs.Stream(ctx, logsRes.LogsURL, filter); err != nil {
-			return errgo.Mask(err, errgo.Any)
-		}
-	}
-	return nil
+	return logsRes.LogsURL, nil
 }
 
 func checkFilter(ctx context.Context, c *scalingo.Client, appName string, filter string) error {
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/logs"
 )
 
 func LogsArchives(ctx context.Context, appName string, page int) error {
@@ -44,3 +45,19 @@ func LogsArchives(ctx context.Context, appName string, page int) error {
 
 	return nil
 }
+
+// LogsArchivesSync downloads the logs archives of the app which are not in
+// the directory yet
+func LogsArchivesSync(ctx context.Context, appName string, opts logs.SyncArchivesOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	opts.App = appName
+	err = logs.SyncArchives(ctx, logs.AppArchivesPager(c, appName), opts)
+	if err != nil {
+		return errgo.Notef(err, "fail to sync the app logs archives")
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package apps
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/errgo.v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// multiAppsConcurrency is the maximum number of apps on which a command runs
+// at the same time
+const multiAppsConcurrency = 4
+
+type multiAppsResult struct {
+	App    string `json:"app"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Output is the output of the command on the app, only filled with a
+	// machine-readable output
+	Output interface{} `json:"output,omitempty"`
+}
+
+// SelectApps returns the names of the apps matching the selector, sorted by
+// name. The selector is a comma-separated list of app names or glob patterns
+// (e.g. 'shop-*', with the syntax of path.Match). All the apps are selected if
+// all is true.
+func SelectApps(ctx context.Context, selector string, all bool) ([]string, error) {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get Scalingo client")
+	}
+	apps, err := c.AppsList(ctx)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to list the apps")
+	}
+
+	names, err := selectApps(apps, selector, all)
+	if err != nil {
+		return nil, errgo.Mask(err)
+	}
+	return names, nil
+}
+
+func selectApps(apps []*scalingo.App, selector string, all bool) ([]string, error) {
+	selected := map[string]bool{}
+	if all {
+		for _, app := range apps {
+			selected[app.Name] = true
+		}
+	}
+
+	for _, pattern := range strings.Split(selector, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errgo.Newf("invalid pattern '%s': %v", pattern, err)
+		}
+
+		matched := false
+		for _, app := range apps {
+			if ok, _ := path.Match(pattern, app.Name); ok {
+				selected[app.Name] = true
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, errgo.Newf("no app matches '%s'", pattern)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, errgo.New("no app selected")
+	}
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// RunOnApps runs action on each app, with at most multiAppsConcurrency apps
+// at the same time, then displays the result of each app. The action must
+// display its output in output.Writer(ctx): the output of each app is captured
+// and displayed as a block under the name of the app, or embedded in the
+// summary if a machine-readable output has been requested. An error is
+// returned if the action failed on at least one app.
+func RunOnApps(ctx context.Context, names []string, action func(ctx context.Context, app string) error) error {
+	results := make([]multiAppsResult, len(names))
+	outputs := make([]*bytes.Buffer, len(names))
+	semaphore := make(chan struct{}, multiAppsConcurrency)
+	wg := &sync.WaitGroup{}
+	for i, name := range names {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			outputs[i] = new(bytes.Buffer)
+			results[i] = multiAppsResult{App: name, Status: "success"}
+			err := action(output.WithWriter(ctx, outputs[i]), name)
+			if err != nil {
+				results[i].Status = "failure"
+				results[i].Error = err.Error()
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	failures := 0
+	for i, r := range results {
+		if r.Status != "success" {
+			failures++
+		}
+		if output.IsHuman() {
+			io.Status(io.Bold(r.App))
+			fmt.Print(outputs[i].String())
+			fmt.Println()
+		} else {
+			results[i].Output = capturedOutput(outputs[i].Bytes())
+		}
+	}
+
+	t := output.NewTable(results)
+	t.SetHeader([]string{"App", "Status", "Error"})
+	for _, r := range results {
+		status := io.Green(r.Status)
+		if r.Status != "success" {
+			status = io.BoldRed(r.Status)
+		}
+		t.Append([]string{r.App, status, r.Error})
+	}
+	err := t.Render()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	if failures > 0 {
+		return errgo.Newf("the command failed on %d of %d apps", failures, len(names))
+	}
+	return nil
+}
+
+// capturedOutput decodes the machine-readable output of an app so that it is
+// embedded as is in the summary. The output is kept as a string if it cannot
+// be decoded, e.g. with a --format template.
+func capturedOutput(out []byte) interface{} {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return nil
+	}
+	switch output.Opts.Format {
+	case output.FormatJSON:
+		if json.Valid(out) {
+			return json.RawMessage(out)
+		}
+	case output.FormatYAML:
+		var document interface{}
+		if yaml.Unmarshal(out, &document) == nil {
+			return document
+		}
+	}
+	return string(out)
+}
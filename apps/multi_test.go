@@ -0,0 +1,107 @@
+package apps
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestSelectApps(t *testing.T) {
+	apps := []*scalingo.App{
+		{Name: "shop-front"},
+		{Name: "shop-api"},
+		{Name: "blog"},
+	}
+
+	tests := map[string]struct {
+		selector      string
+		all           bool
+		expectedNames []string
+		expectedError string
+	}{
+		"comma-separated list": {
+			selector:      "blog, shop-api",
+			expectedNames: []string{"blog", "shop-api"},
+		},
+		"glob pattern": {
+			selector:      "shop-*",
+			expectedNames: []string{"shop-api", "shop-front"},
+		},
+		"duplicated apps are selected once": {
+			selector:      "shop-*,shop-api",
+			expectedNames: []string{"shop-api", "shop-front"},
+		},
+		"all the apps": {
+			all:           true,
+			expectedNames: []string{"blog", "shop-api", "shop-front"},
+		},
+		"unknown app": {
+			selector:      "blog,unknown",
+			expectedError: "no app matches 'unknown'",
+		},
+		"invalid pattern": {
+			selector:      "shop-[",
+			expectedError: "invalid pattern 'shop-['",
+		},
+		"empty selector": {
+			selector:      " , ",
+			expectedError: "no app selected",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			names, err := selectApps(apps, test.selector, test.all)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedNames, names)
+		})
+	}
+}
+
+func TestCapturedOutput(t *testing.T) {
+	defer func() { output.Opts = output.Options{Format: output.FormatTable} }()
+
+	tests := map[string]struct {
+		format   output.Format
+		out      string
+		expected interface{}
+	}{
+		"an empty output is omitted": {
+			format:   output.FormatJSON,
+			out:      "\n",
+			expected: nil,
+		},
+		"a JSON document is embedded as is": {
+			format:   output.FormatJSON,
+			out:      "[\n  {\"name\": \"web-1\"}\n]\n",
+			expected: json.RawMessage("[\n  {\"name\": \"web-1\"}\n]"),
+		},
+		"a YAML document is decoded": {
+			format:   output.FormatYAML,
+			out:      "- name: web-1\n",
+			expected: []interface{}{map[string]interface{}{"name": "web-1"}},
+		},
+		"a text output is kept as a string": {
+			format:   output.FormatJSON,
+			out:      "Your application is being restarted.\n",
+			expected: "Your application is being restarted.",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			output.Opts = output.Options{Format: test.format}
+			assert.Equal(t, test.expected, capturedOutput([]byte(test.out)))
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -51,10 +52,13 @@ func handleOperation(ctx context.Context, app string, res *http.Response) error
 		}
 	}()
 
-	fmt.Print("Status:  ")
-	spinner := io.NewSpinner(os.Stderr)
-	go spinner.Start()
-	defer spinner.Stop()
+	w := output.Writer(ctx)
+	fmt.Fprint(w, "Status:  ")
+	if !output.IsCaptured(ctx) {
+		spinner := io.NewSpinner(os.Stderr)
+		go spinner.Start()
+		defer spinner.Stop()
+	}
 
 	for {
 		select {
@@ -62,10 +66,10 @@ func handleOperation(ctx context.Context, app string, res *http.Response) error
 			return errgo.Mask(err)
 		case <-done:
 			if op.Status == "done" {
-				fmt.Printf("\bDone in %.3f seconds\n", op.ElapsedDuration())
+				fmt.Fprintf(w, "\bDone in %.3f seconds\n", op.ElapsedDuration())
 				return nil
 			} else if op.Status == "error" {
-				fmt.Printf("\bOperation '%s' failed, an error occurred: %v\n", op.Type, op.Error)
+				fmt.Fprintf(w, "\bOperation '%s' failed, an error occurred: %v\n", op.Type, op.Error)
 				return nil
 			}
 		}
@@ -2,12 +2,11 @@ package apps
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/cli/utils"
 )
 
@@ -22,12 +21,12 @@ func Ps(ctx context.Context, app string) error {
 		return errgo.Notef(err, "fail to list the application containers")
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(containers)
+	t.SetWriter(output.Writer(ctx))
 	t.SetHeader([]string{"Name", "Status", "Command", "Size", "Created At"})
 
 	for _, container := range containers {
 		t.Append([]string{container.Label, container.State, container.Command, container.ContainerSize.HumanName, container.CreatedAt.Format(utils.TimeFormat)})
 	}
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -7,6 +7,7 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -25,7 +26,7 @@ func Restart(ctx context.Context, app string, sync bool, args []string) error {
 	res.Body.Close()
 
 	if !sync {
-		fmt.Println("Your application is being restarted.")
+		fmt.Fprintln(output.Writer(ctx), "Your application is being restarted.")
 		return nil
 	}
 
@@ -34,6 +35,6 @@ func Restart(ctx context.Context, app string, sync bool, args []string) error {
 		return errgo.Mask(err)
 	}
 
-	fmt.Println("Your application has been restarted.")
+	fmt.Fprintln(output.Writer(ctx), "Your application has been restarted.")
 	return nil
 }
@@ -86,6 +86,11 @@ func Run(ctx context.Context, opts RunOpts) error {
 
 	if opts.Size == "" {
 		opts.Size = "M"
+	} else {
+		opts.Size, err = checkContainerSize(containerSizesCatalogue(ctx, c), opts.Size)
+		if err != nil {
+			return errgo.Mask(err)
+		}
 	}
 
 	if opts.CmdEnv == nil {
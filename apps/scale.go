@@ -12,6 +12,7 @@ import (
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/cli/utils"
 	"github.com/Scalingo/go-scalingo/v6"
 	"github.com/Scalingo/go-scalingo/v6/debug"
@@ -23,12 +24,19 @@ type ScaleRes struct {
 	Containers []scalingo.ContainerType `json:"containers"`
 }
 
-func Scale(ctx context.Context, app string, sync bool, types []string) error {
+type ScaleOpts struct {
+	Sync bool
+	// Yes skips the confirmation of the scaling plan
+	Yes bool
+	// DryRun only displays the scaling plan
+	DryRun bool
+}
+
+func Scale(ctx context.Context, app string, types []string, opts ScaleOpts) error {
 	var (
-		size           string
-		containerTypes []scalingo.ContainerType
-		modificator    byte
-		err            error
+		size        string
+		modificator byte
+		err         error
 	)
 
 	c, err := config.ScalingoClient(ctx)
@@ -41,6 +49,11 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 	if err != nil {
 		return errgo.Notef(err, "fail to list the autoscalers")
 	}
+	containerTypes, err := c.AppsContainerTypes(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to get list of running containers")
+	}
+	debug.Println("get container list", containerTypes)
 
 	for _, t := range types {
 		splitT := strings.Split(t, ":")
@@ -58,13 +71,6 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 			if size != "" {
 				return errgo.Newf("%s is invalid, can't use relative modificator with size, change the size first", t)
 			}
-			if containerTypes == nil {
-				containerTypes, err = c.AppsContainerTypes(ctx, app)
-				if err != nil {
-					return errgo.Notef(err, "fail to get list of running containers")
-				}
-				debug.Println("get container list", containerTypes)
-			}
 		}
 
 		amount, err := strconv.ParseInt(typeAmount, 10, 32)
@@ -98,8 +104,31 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 		scaleParams.Containers = append(scaleParams.Containers, newContainerConfig)
 	}
 
-	if len(typesWithAutoscaler) > 0 {
-		io.Warning(autoscaleDisableMessage(typesWithAutoscaler))
+	sizes := containerSizesCatalogue(ctx, c)
+	for i, container := range scaleParams.Containers {
+		if container.Size == "" {
+			continue
+		}
+		scaleParams.Containers[i].Size, err = checkContainerSize(sizes, container.Size)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+	}
+
+	err = displayScalePlan(output.Writer(ctx), newScalePlan(containerTypes, scaleParams.Containers, sizes))
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	if opts.DryRun {
+		return nil
+	}
+
+	if !opts.Yes {
+		if len(typesWithAutoscaler) > 0 {
+			io.Warning(autoscaleDisableMessage(typesWithAutoscaler))
+		} else {
+			io.Info("Do you confirm? (y/N)")
+		}
 
 		var confirm string
 		fmt.Scanln(&confirm)
@@ -125,7 +154,9 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 		}
 		// If error is Payment Required and user tries to exceed its free trial
 		return utils.AskAndStopFreeTrial(ctx, c, func() error {
-			return Scale(ctx, app, sync, types)
+			// The plan has already been confirmed
+			opts.Yes = true
+			return Scale(ctx, app, types, opts)
 		})
 	}
 	defer res.Body.Close()
@@ -136,12 +167,13 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 		return errgo.Notef(err, "fail to decode API response to scale operation")
 	}
 
-	fmt.Printf("Your application is being scaled to:\n")
+	w := output.Writer(ctx)
+	fmt.Fprintf(w, "Your application is being scaled to:\n")
 	for _, ct := range scaleRes.Containers {
-		fmt.Println(io.Indent(fmt.Sprintf("%s: %d - %s", ct.Name, ct.Amount, ct.Size), 2))
+		fmt.Fprintln(w, io.Indent(fmt.Sprintf("%s: %d - %s", ct.Name, ct.Amount, ct.Size), 2))
 	}
 
-	if !sync {
+	if !opts.Sync {
 		return nil
 	}
 
@@ -150,7 +182,7 @@ func Scale(ctx context.Context, app string, sync bool, types []string) error {
 		return errgo.Notef(err, "fail to handle the scale operation")
 	}
 
-	fmt.Println("Your application has been scaled.")
+	fmt.Fprintln(w, "Your application has been scaled.")
 	return nil
 }
 
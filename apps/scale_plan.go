@@ -0,0 +1,150 @@
+package apps
+
+import (
+	"fmt"
+	stdio "io"
+
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// defaultContainerSize is the size given by the API to a container type
+// scaled without size
+const defaultContainerSize = "M"
+
+// scalePlanItem describes the change of a container type. The monthly prices
+// are in cents, they are nil if the size is not in the catalogue.
+type scalePlanItem struct {
+	Type               string `json:"type"`
+	AmountBefore       int    `json:"amount_before"`
+	SizeBefore         string `json:"size_before"`
+	AmountAfter        int    `json:"amount_after"`
+	SizeAfter          string `json:"size_after"`
+	MonthlyPriceBefore *int   `json:"monthly_price_before"`
+	MonthlyPriceAfter  *int   `json:"monthly_price_after"`
+}
+
+type scalePlan []scalePlanItem
+
+// newScalePlan computes the formation of the app after the scaling. All the
+// container types of the app are part of the plan, even the ones not scaled,
+// so that the totals are the cost of the whole formation.
+func newScalePlan(current []scalingo.ContainerType, scaled []scalingo.ContainerType, sizes []scalingo.ContainerSize) scalePlan {
+	plan := scalePlan{}
+	indexes := map[string]int{}
+	for _, ct := range current {
+		indexes[ct.Name] = len(plan)
+		plan = append(plan, scalePlanItem{
+			Type:         ct.Name,
+			AmountBefore: ct.Amount,
+			SizeBefore:   ct.Size,
+			AmountAfter:  ct.Amount,
+			SizeAfter:    ct.Size,
+		})
+	}
+
+	for _, ct := range scaled {
+		index, ok := indexes[ct.Name]
+		if !ok {
+			index = len(plan)
+			indexes[ct.Name] = index
+			plan = append(plan, scalePlanItem{Type: ct.Name})
+		}
+		plan[index].AmountAfter = ct.Amount
+		if ct.Size != "" {
+			plan[index].SizeAfter = ct.Size
+		}
+	}
+
+	for i, item := range plan {
+		if item.SizeAfter == "" {
+			plan[i].SizeAfter = defaultContainerSize
+		}
+		plan[i].MonthlyPriceBefore = monthlyPrice(sizes, item.SizeBefore, item.AmountBefore)
+		plan[i].MonthlyPriceAfter = monthlyPrice(sizes, plan[i].SizeAfter, item.AmountAfter)
+	}
+	return plan
+}
+
+// totals returns the monthly cost of the formation before and after the
+// scaling. ok is false if the price of one of the sizes is unknown.
+func (p scalePlan) totals() (before int, after int, ok bool) {
+	for _, item := range p {
+		if item.MonthlyPriceBefore == nil || item.MonthlyPriceAfter == nil {
+			return 0, 0, false
+		}
+		before += *item.MonthlyPriceBefore
+		after += *item.MonthlyPriceAfter
+	}
+	return before, after, true
+}
+
+func monthlyPrice(sizes []scalingo.ContainerSize, size string, amount int) *int {
+	if amount == 0 {
+		price := 0
+		return &price
+	}
+	for _, s := range sizes {
+		if s.Name == size {
+			price := s.ThirtydaysPrice * amount
+			return &price
+		}
+	}
+	return nil
+}
+
+func displayScalePlan(w stdio.Writer, plan scalePlan) error {
+	t := output.NewTable(plan)
+	t.SetWriter(w)
+	t.SetHeader([]string{"Type", "Before", "After", "Monthly Cost Before", "Monthly Cost After"})
+	for _, item := range plan {
+		after := formatFormation(item.AmountAfter, item.SizeAfter)
+		if item.AmountAfter != item.AmountBefore || item.SizeAfter != item.SizeBefore {
+			after = io.Yellow(after)
+		}
+		t.Append([]string{
+			item.Type,
+			formatFormation(item.AmountBefore, item.SizeBefore),
+			after,
+			formatOptionalPrice(item.MonthlyPriceBefore),
+			formatOptionalPrice(item.MonthlyPriceAfter),
+		})
+	}
+	err := t.Render()
+	if err != nil {
+		return err
+	}
+	if !output.IsHuman() {
+		return nil
+	}
+
+	before, after, ok := plan.totals()
+	if !ok {
+		io.Fwarning(w, "The cost of the scaling cannot be computed, some container sizes are unknown")
+		return nil
+	}
+	delta := utils.FormatPrice(after - before)
+	if after > before {
+		delta = io.BoldRed("+" + delta)
+	} else if after < before {
+		delta = io.Green(delta)
+	}
+	fmt.Fprintf(w, "Monthly cost of the containers: %s → %s (%s)\n", utils.FormatPrice(before), utils.FormatPrice(after), delta)
+	return nil
+}
+
+func formatFormation(amount int, size string) string {
+	if amount == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%d - %s", amount, size)
+}
+
+func formatOptionalPrice(price *int) string {
+	if price == nil {
+		return "-"
+	}
+	return utils.FormatPrice(*price)
+}
@@ -0,0 +1,71 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestNewScalePlan(t *testing.T) {
+	sizes := []scalingo.ContainerSize{
+		{Name: "M", ThirtydaysPrice: 720},
+		{Name: "2XL", ThirtydaysPrice: 5760},
+	}
+	current := []scalingo.ContainerType{
+		{Name: "web", Amount: 2, Size: "M"},
+		{Name: "worker", Amount: 1, Size: "M"},
+	}
+
+	tests := map[string]struct {
+		scaled         []scalingo.ContainerType
+		expectedAfter  map[string]string
+		expectedBefore int
+		expectedTotal  int
+	}{
+		"it should keep the size of the container type": {
+			scaled:         []scalingo.ContainerType{{Name: "web", Amount: 3}},
+			expectedAfter:  map[string]string{"web": "3 - M", "worker": "1 - M"},
+			expectedBefore: 2160,
+			expectedTotal:  2880,
+		},
+		"it should change the size of the container type": {
+			scaled:         []scalingo.ContainerType{{Name: "web", Amount: 20, Size: "2XL"}},
+			expectedAfter:  map[string]string{"web": "20 - 2XL", "worker": "1 - M"},
+			expectedBefore: 2160,
+			expectedTotal:  115920,
+		},
+		"it should add a new container type with the default size": {
+			scaled:         []scalingo.ContainerType{{Name: "clock", Amount: 1}, {Name: "worker", Amount: 0}},
+			expectedAfter:  map[string]string{"web": "2 - M", "worker": "0", "clock": "1 - M"},
+			expectedBefore: 2160,
+			expectedTotal:  2160,
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			plan := newScalePlan(current, test.scaled, sizes)
+
+			after := map[string]string{}
+			for _, item := range plan {
+				after[item.Type] = formatFormation(item.AmountAfter, item.SizeAfter)
+			}
+			assert.Equal(t, test.expectedAfter, after)
+
+			before, total, ok := plan.totals()
+			require.True(t, ok)
+			assert.Equal(t, test.expectedBefore, before)
+			assert.Equal(t, test.expectedTotal, total)
+		})
+	}
+}
+
+func TestScalePlan_TotalsUnknownSize(t *testing.T) {
+	plan := newScalePlan(nil, []scalingo.ContainerType{{Name: "web", Amount: 1, Size: "XL"}}, nil)
+
+	_, _, ok := plan.totals()
+	assert.False(t, ok)
+}
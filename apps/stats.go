@@ -3,14 +3,13 @@ package apps
 import (
 	"context"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/dustin/go-humanize"
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -31,7 +30,10 @@ func Stats(ctx context.Context, app string, stream bool) error {
 		if err != nil {
 			return errgo.Mask(err)
 		}
-		displayLiveStatsTable(stats.Stats)
+		err = displayLiveStatsTable(stats.Stats)
+		if err != nil {
+			return errgo.Mask(err)
+		}
 
 		ticker := time.NewTicker(10 * time.Second)
 		for {
@@ -42,7 +44,11 @@ func Stats(ctx context.Context, app string, stream bool) error {
 					ticker.Stop()
 					return errgo.Mask(err)
 				}
-				displayLiveStatsTable(stats.Stats)
+				err = displayLiveStatsTable(stats.Stats)
+				if err != nil {
+					ticker.Stop()
+					return errgo.Mask(err)
+				}
 			}
 		}
 	} else {
@@ -54,15 +60,23 @@ func Stats(ctx context.Context, app string, stream bool) error {
 	}
 }
 
-func displayLiveStatsTable(stats []*scalingo.ContainerStat) {
+func displayLiveStatsTable(stats []*scalingo.ContainerStat) error {
+	if !output.IsHuman() {
+		return displayStatsTable(stats)
+	}
+
 	fmt.Print("\033[2J\033[;H")
 	fmt.Printf("Refreshing every 10 seconds...\n\n")
-	displayStatsTable(stats)
+	err := displayStatsTable(stats)
+	if err != nil {
+		return errgo.Mask(err)
+	}
 	fmt.Println("Last update at:", time.Now().Format(time.UnixDate))
+	return nil
 }
 
 func displayStatsTable(stats []*scalingo.ContainerStat) error {
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(stats)
 	t.SetHeader([]string{"Name", "CPU", "Memory", "Swap"})
 
 	for i, s := range stats {
@@ -92,6 +106,5 @@ func displayStatsTable(stats []*scalingo.ContainerStat) error {
 		}
 	}
 
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -0,0 +1,114 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// Transfer gives the ownership of an app to the user with the given email
+func Transfer(ctx context.Context, appName, email string, force bool) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	_, err = c.AppsShow(ctx, appName)
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+
+	if !force {
+		fmt.Printf("/!\\ You're going to transfer %s to %s, you will lose its ownership.\nTo confirm type the name of the application: ", appName, email)
+		err = confirmValidation(appName)
+		if err != nil {
+			return errgo.Mask(err, errgo.Any)
+		}
+	}
+
+	_, err = c.AppsTransfer(ctx, appName, email)
+	if err != nil {
+		return errgo.Notef(err, "fail to transfer app")
+	}
+
+	io.Status("App " + appName + " has been transferred to " + email)
+	return nil
+}
+
+// TransferMatching gives the ownership of all the apps owned by the current
+// user whose name matches the pattern to the user with the given email. The
+// pattern syntax is the one of path.Match (e.g. 'my-project-*').
+func TransferMatching(ctx context.Context, pattern, email string, force bool) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return errgo.Newf("invalid pattern '%s': %v", pattern, err)
+	}
+
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	currentUser, err := config.C.CurrentUser()
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current user")
+	}
+
+	apps, err := c.AppsList(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the apps")
+	}
+
+	matchingApps := ownedAppsMatching(apps, currentUser.ID, pattern)
+	if len(matchingApps) == 0 {
+		io.Statusf("No app owned by %s matches '%s'\n", currentUser.Username, pattern)
+		return nil
+	}
+
+	if !force {
+		fmt.Printf("/!\\ You're going to transfer the following apps to %s, you will lose their ownership:\n", email)
+		for _, app := range matchingApps {
+			fmt.Printf("  - %s\n", app.Name)
+		}
+		fmt.Print("To confirm type the email of the new owner: ")
+		err = confirmValidation(email)
+		if err != nil {
+			return errgo.Mask(err, errgo.Any)
+		}
+	}
+
+	var failures []string
+	for _, app := range matchingApps {
+		_, err = c.AppsTransfer(ctx, app.Name, email)
+		if err != nil {
+			io.Errorf("Fail to transfer %s: %v\n", app.Name, err)
+			failures = append(failures, app.Name)
+			continue
+		}
+		io.Status("App " + app.Name + " has been transferred to " + email)
+	}
+
+	if len(failures) != 0 {
+		return errgo.Newf("%d app(s) have not been transferred: %s", len(failures), strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+func ownedAppsMatching(apps []*scalingo.App, ownerID, pattern string) []*scalingo.App {
+	var res []*scalingo.App
+	for _, app := range apps {
+		if app.Owner.ID != ownerID {
+			continue
+		}
+		if matched, _ := path.Match(pattern, app.Name); matched {
+			res = append(res, app)
+		}
+	}
+	return res
+}
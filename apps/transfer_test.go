@@ -0,0 +1,46 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestOwnedAppsMatching(t *testing.T) {
+	apps := []*scalingo.App{
+		{Name: "project-front", Owner: scalingo.Owner{ID: "us-1"}},
+		{Name: "project-api", Owner: scalingo.Owner{ID: "us-1"}},
+		{Name: "project-shared", Owner: scalingo.Owner{ID: "us-2"}},
+		{Name: "other", Owner: scalingo.Owner{ID: "us-1"}},
+	}
+
+	tests := map[string]struct {
+		pattern  string
+		expected []string
+	}{
+		"it should only keep the apps of the owner matching the pattern": {
+			pattern:  "project-*",
+			expected: []string{"project-front", "project-api"},
+		},
+		"it should match an exact name": {
+			pattern:  "other",
+			expected: []string{"other"},
+		},
+		"it should not match the apps of other owners": {
+			pattern:  "project-shared",
+			expected: nil,
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			var names []string
+			for _, app := range ownedAppsMatching(apps, "us-1", test.pattern) {
+				names = append(names, app.Name)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
@@ -3,12 +3,11 @@ package autoscalers
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context, app string) error {
@@ -21,7 +20,7 @@ func List(ctx context.Context, app string) error {
 		return errgo.Mask(err, errgo.Any)
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(autoscalers)
 	t.SetHeader([]string{"Active", "Container type", "Metric", "Target", "Min containers", "Max containers"})
 
 	for _, autoscaler := range autoscalers {
@@ -32,6 +31,5 @@ func List(ctx context.Context, app string) error {
 			fmt.Sprintf("%d", autoscaler.MinContainers), fmt.Sprintf("%d", autoscaler.MaxContainers),
 		})
 	}
-	t.Render()
-	return nil
+	return t.Render()
 }
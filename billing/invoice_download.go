@@ -0,0 +1,81 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	appio "github.com/Scalingo/cli/io"
+	httpclient "github.com/Scalingo/go-scalingo/v6/http"
+)
+
+type DownloadInvoiceOpts struct {
+	// Output is the path of the PDF file, or of the directory where the file
+	// is written. "-" writes the file on stdout.
+	Output string
+}
+
+func DownloadInvoice(ctx context.Context, id string, opts DownloadInvoiceOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	i, err := c.InvoiceShow(ctx, id)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the invoice")
+	}
+	if i.PdfURL == "" {
+		return errgo.Newf("no PDF is available for the invoice %s yet", id)
+	}
+
+	resp, err := http.Get(i.PdfURL)
+	if err != nil {
+		return errgo.Notef(err, "fail to start download")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return httpclient.NewRequestFailedError(resp, &httpclient.APIRequest{
+			URL:    i.PdfURL,
+			Method: "GET",
+		})
+	}
+
+	if opts.Output == "-" {
+		_, err = io.Copy(os.Stdout, resp.Body)
+		if err != nil {
+			return errgo.Notef(err, "fail to download the invoice")
+		}
+		return nil
+	}
+
+	path := fmt.Sprintf("invoice-%s.pdf", i.InvoiceNumber)
+	if opts.Output != "" {
+		if stat, err := os.Stat(opts.Output); err == nil && stat.IsDir() {
+			path = filepath.Join(opts.Output, path)
+		} else {
+			path = opts.Output
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errgo.Notef(err, "fail to open file")
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, resp.Body)
+	if err != nil {
+		return errgo.Notef(err, "fail to download the invoice")
+	}
+
+	appio.Statusf("Invoice %s has been saved to %s\n", i.InvoiceNumber, path)
+	return nil
+}
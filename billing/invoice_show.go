@@ -0,0 +1,49 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/utils"
+)
+
+func ShowInvoice(ctx context.Context, id string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	i, err := c.InvoiceShow(ctx, id)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the invoice")
+	}
+
+	if rendered, err := output.Data(newInvoice(i)); rendered {
+		return errgo.Mask(err)
+	}
+
+	fmt.Printf("%s: %s\n", io.Yellow("Invoice"), i.InvoiceNumber)
+	fmt.Printf("%s: %s\n", io.Yellow("Month"), billingMonth(i).Format(monthFormat))
+	fmt.Printf("%s: %s\n", io.Yellow("State"), i.State)
+	fmt.Println()
+
+	t := output.NewTable(i.DetailedItems)
+	t.SetHeader([]string{"App", "Item", "Price"})
+	if len(i.DetailedItems) != 0 {
+		for _, item := range i.DetailedItems {
+			t.Append([]string{item.App, item.Label, utils.FormatPrice(item.Price)})
+		}
+	} else {
+		for _, item := range i.Items {
+			t.Append([]string{"", item.Label, utils.FormatPrice(item.Price)})
+		}
+	}
+	t.Append([]string{"", "Total", utils.FormatPrice(i.TotalPrice)})
+	t.Append([]string{"", "Total with VAT", utils.FormatPrice(i.TotalPriceWithVat)})
+	return t.Render()
+}
@@ -0,0 +1,108 @@
+// Package billing gathers the command handlers related to the billing of the
+// account: invoices and billing profile
+package billing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+const (
+	monthFormat = "2006-01"
+)
+
+type ListInvoicesOpts struct {
+	PaginationOpts scalingo.PaginationOpts
+	// Month only keeps the invoice of the given month, with the format YYYY-MM
+	Month string
+}
+
+// invoice overrides the billing month of the go-scalingo invoice which can't
+// be encoded in JSON otherwise
+type invoice struct {
+	*scalingo.Invoice
+	BillingMonth string `json:"billing_month"`
+}
+
+func newInvoice(i *scalingo.Invoice) invoice {
+	return invoice{Invoice: i, BillingMonth: billingMonth(i).Format(monthFormat)}
+}
+
+func ListInvoices(ctx context.Context, opts ListInvoicesOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	var invoices scalingo.Invoices
+	var pagination scalingo.PaginationMeta
+	if opts.Month != "" {
+		invoices, err = invoicesOfMonth(ctx, c, opts.Month)
+	} else {
+		invoices, pagination, err = c.InvoicesList(ctx, opts.PaginationOpts)
+	}
+	if err != nil {
+		return errgo.Notef(err, "fail to list the invoices")
+	}
+
+	displayedInvoices := make([]invoice, 0, len(invoices))
+	t := output.NewTable(&displayedInvoices)
+	t.SetHeader([]string{"ID", "Number", "Month", "Total", "Total with VAT", "State"})
+	for _, i := range invoices {
+		displayedInvoices = append(displayedInvoices, newInvoice(i))
+		t.Append([]string{
+			i.ID, i.InvoiceNumber, billingMonth(i).Format(monthFormat),
+			utils.FormatPrice(i.TotalPrice), utils.FormatPrice(i.TotalPriceWithVat), i.State,
+		})
+	}
+	err = t.Render()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	if opts.Month == "" {
+		fmt.Fprintln(os.Stderr, io.Gray(fmt.Sprintf("Page: %d, Last Page: %d", pagination.CurrentPage, pagination.TotalPages)))
+	}
+	return nil
+}
+
+// invoicesOfMonth goes through all the pages of invoices to find the ones of
+// the given month
+func invoicesOfMonth(ctx context.Context, c *scalingo.Client, month string) (scalingo.Invoices, error) {
+	target, err := time.Parse(monthFormat, month)
+	if err != nil {
+		return nil, errgo.Newf("invalid month '%s', the expected format is YYYY-MM", month)
+	}
+
+	var res scalingo.Invoices
+	opts := scalingo.PaginationOpts{Page: 1, PerPage: 50}
+	for {
+		invoices, pagination, err := c.InvoicesList(ctx, opts)
+		if err != nil {
+			return nil, errgo.Mask(err)
+		}
+		for _, i := range invoices {
+			if billingMonth(i).Format(monthFormat) == target.Format(monthFormat) {
+				res = append(res, i)
+			}
+		}
+		if pagination.NextPage == 0 || len(invoices) == 0 {
+			return res, nil
+		}
+		opts.Page = pagination.NextPage
+	}
+}
+
+func billingMonth(i *scalingo.Invoice) time.Time {
+	return time.Time(i.BillingMonth)
+}
@@ -0,0 +1,121 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
+	scalingobilling "github.com/Scalingo/go-scalingo/v6/billing"
+	httpclient "github.com/Scalingo/go-scalingo/v6/http"
+)
+
+// go-scalingo only provides the billing profile structure, the requests to
+// the authentication API are done here: the profile of the current user is
+// read with GET /v1/billing_profile and updated with
+// PUT /v1/billing_profiles/:id.
+
+type profile struct {
+	ID string `json:"id"`
+	scalingobilling.Profile
+}
+
+type profileRes struct {
+	Profile profile `json:"billing_profile"`
+}
+
+type UpdateProfileParams struct {
+	Company   *string `json:"company,omitempty"`
+	VATNumber *string `json:"vat_number,omitempty"`
+}
+
+type updateProfileReq struct {
+	Profile UpdateProfileParams `json:"billing_profile"`
+}
+
+func ShowProfile(ctx context.Context) error {
+	c, err := config.ScalingoAuthClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	p, err := getProfile(ctx, c)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	if rendered, err := output.Data(p); rendered {
+		return errgo.Mask(err)
+	}
+	displayProfile(p)
+	return nil
+}
+
+func UpdateProfile(ctx context.Context, params UpdateProfileParams) error {
+	if params.Company == nil && params.VATNumber == nil {
+		return errgo.New("nothing to update, at least one of --company or --vat-number is required")
+	}
+
+	c, err := config.ScalingoAuthClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	p, err := updateProfile(ctx, c, params)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	if rendered, err := output.Data(p); rendered {
+		return errgo.Mask(err)
+	}
+	io.Status("Your billing profile has been updated")
+	displayProfile(p)
+	return nil
+}
+
+func updateProfile(ctx context.Context, c *scalingo.Client, params UpdateProfileParams) (profile, error) {
+	p, err := getProfile(ctx, c)
+	if err != nil {
+		return profile{}, errgo.Mask(err)
+	}
+
+	var res profileRes
+	err = c.AuthAPI().DoRequest(ctx, &httpclient.APIRequest{
+		Method:   "PUT",
+		Endpoint: "/billing_profiles/" + p.ID,
+		Params:   updateProfileReq{Profile: params},
+		Expected: httpclient.Statuses{200},
+	}, &res)
+	if err != nil {
+		return profile{}, errgo.Notef(err, "fail to update the billing profile")
+	}
+	return res.Profile, nil
+}
+
+func getProfile(ctx context.Context, c *scalingo.Client) (profile, error) {
+	var res profileRes
+	err := c.AuthAPI().ResourceList(ctx, "billing_profile", nil, &res)
+	if err != nil {
+		return profile{}, errgo.Notef(err, "fail to get the billing profile")
+	}
+	return res.Profile, nil
+}
+
+func displayProfile(p profile) {
+	fmt.Printf("%s: %s\n", io.Yellow("Company"), p.Company)
+	fmt.Printf("%s: %s\n", io.Yellow("VAT Number"), p.VATNumber)
+
+	paymentMethod := string(p.PaymentMethodType)
+	if p.PaymentMethodType == scalingobilling.Stripe && p.Stripe.Last4 != "" {
+		paymentMethod = fmt.Sprintf("%s card ending with %s (expires %s)", p.Stripe.Brand, p.Stripe.Last4, p.Stripe.Exp)
+	}
+	if paymentMethod == "" {
+		paymentMethod = "None"
+	}
+	fmt.Printf("%s: %s\n", io.Yellow("Payment Method"), paymentMethod)
+}
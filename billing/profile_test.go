@@ -0,0 +1,46 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestUpdateProfile(t *testing.T) {
+	var updateBody map[string]map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/billing_profile":
+			w.Write([]byte(`{"billing_profile": {"id": "bp-1", "company": "Old Company", "vat_number": "FR1"}}`))
+		case "PUT /v1/billing_profiles/bp-1":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&updateBody))
+			w.Write([]byte(`{"billing_profile": {"id": "bp-1", "company": "My Company", "vat_number": "FR1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := scalingo.New(context.Background(), scalingo.ClientConfig{
+		AuthEndpoint:         server.URL,
+		StaticTokenGenerator: scalingo.NewStaticTokenGenerator("token"),
+	})
+	require.NoError(t, err)
+
+	company := "My Company"
+	p, err := updateProfile(context.Background(), c, UpdateProfileParams{Company: &company})
+	require.NoError(t, err)
+
+	// Only the given fields are sent
+	assert.Equal(t, map[string]map[string]string{"billing_profile": {"company": "My Company"}}, updateBody)
+	assert.Equal(t, "bp-1", p.ID)
+	assert.Equal(t, "My Company", p.Company)
+	assert.Equal(t, "FR1", p.VATNumber)
+}
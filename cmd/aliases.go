@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/config"
+)
+
+const aliasesCategory = "Aliases"
+
+var unquotedArgRe = regexp.MustCompile(`^[\w@%+=:,./$-]+$`)
+
+var (
+	aliasSetCommand = cli.Command{
+		Name:      "alias-set",
+		Category:  "Global",
+		Usage:     "Create or replace a command alias",
+		ArgsUsage: "alias-name command-line",
+		Description: `Create or replace an alias running a full command line. The command line is everything after
+   the alias name, or a single quoted argument. It is saved in the 'aliases' section of the
+   configuration file.
+
+   In the command line, '$1', '$2'… are replaced by the arguments given after the alias and
+   '$@' by all of them. The arguments which are not used this way are appended to the command
+   line. An alias can't have the name of a built-in command.
+
+   Example
+     'scalingo alias-set prod-logs --app shop-prod logs -f -n 500 --filter web'
+     'scalingo prod-logs'
+     'scalingo alias-set prod-logs --app shop-prod logs -f -n 500 --filter '\''$1'\'''
+     'scalingo prod-logs worker'
+
+   # See also 'alias-list' and 'alias-unset'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() < 2 {
+				cli.ShowCommandHelp(c, "alias-set")
+				return nil
+			}
+			name := c.Args().First()
+			commandLine := c.Args().Get(1)
+			if c.Args().Len() > 2 {
+				commandLine = joinCommandLine(c.Args().Tail())
+			}
+
+			if isBuiltinCommand(c.App.Commands, name) {
+				errorQuit(errgo.Newf("'%s' is a built-in command, it can't be an alias", name))
+			}
+			err := config.SetAlias(name, commandLine)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "alias-set")
+		},
+	}
+
+	aliasListCommand = cli.Command{
+		Name:     "alias-list",
+		Category: "Global",
+		Usage:    "List the command aliases",
+		Description: `List the command aliases with the command line they run.
+
+   Example
+     'scalingo alias-list'
+
+   # See also 'alias-set' and 'alias-unset'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "alias-list")
+				return nil
+			}
+			err := config.ListAliases()
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "alias-list")
+		},
+	}
+
+	aliasUnsetCommand = cli.Command{
+		Name:      "alias-unset",
+		Category:  "Global",
+		Usage:     "Remove a command alias",
+		ArgsUsage: "alias-name",
+		Description: `Remove a command alias.
+
+   Example
+     'scalingo alias-unset prod-logs'
+
+   # See also 'alias-set' and 'alias-list'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "alias-unset")
+				return nil
+			}
+			err := config.UnsetAlias(c.Args().First())
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "alias-unset")
+		},
+	}
+)
+
+// AliasCommands returns a command for each alias which is not shadowed by a
+// built-in command, so that the aliases are listed by the help and the
+// autocompletion. These commands are never run, ExpandAlias replaces the
+// alias before.
+func AliasCommands(builtinCommands []*cli.Command) []*cli.Command {
+	var commands []*cli.Command
+	for _, alias := range config.Aliases() {
+		if isBuiltinCommand(builtinCommands, alias.Name) {
+			continue
+		}
+		commands = append(commands, &cli.Command{
+			Name:     alias.Name,
+			Category: aliasesCategory,
+			Usage:    "Alias of 'scalingo " + alias.Command + "'",
+			Description: `Alias of 'scalingo ` + alias.Command + `', defined with 'alias-set'.
+
+   # See also 'alias-list'`,
+			SkipFlagParsing: true,
+		})
+	}
+	return commands
+}
+
+// ExpandAlias replaces the alias used as command in args by its command line
+func ExpandAlias(app *cli.App, args []string) ([]string, error) {
+	aliases := map[string]string{}
+	for name, commandLine := range config.C.ConfigFile.Aliases {
+		if !isBuiltinCommand(app.Commands, name) {
+			aliases[name] = commandLine
+		}
+	}
+	if len(aliases) == 0 {
+		return args, nil
+	}
+
+	flagsWithValue := map[string]bool{}
+	for _, flag := range app.Flags {
+		if _, ok := flag.(*cli.BoolFlag); ok {
+			continue
+		}
+		for _, name := range flag.Names() {
+			flagsWithValue[name] = true
+		}
+	}
+	return config.ExpandAlias(aliases, args, flagsWithValue)
+}
+
+func isBuiltinCommand(commands []*cli.Command, name string) bool {
+	for _, command := range commands {
+		if command.Category != aliasesCategory && command.HasName(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinCommandLine joins args in a command line, the arguments with special
+// characters are single-quoted. Contrary to a shell, '$' is kept as is for the
+// positional parameters of the alias.
+func joinCommandLine(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if !unquotedArgRe.MatchString(arg) {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -46,4 +46,43 @@ var (
 			autocomplete.CmdFlagsAutoComplete(c, "apps-info")
 		},
 	}
+
+	transferCommand = cli.Command{
+		Name:     "transfer",
+		Category: "App Management",
+		Flags: []cli.Flag{
+			&appFlag,
+			&cli.StringFlag{Name: "to", Usage: "Email of the new owner of the app", Required: true},
+			&cli.StringFlag{Name: "pattern", Usage: "Transfer all the apps you own whose name matches the pattern (e.g. 'my-project-*')"},
+			&cli.BoolFlag{Name: "force", Usage: "Transfer without asking for a confirmation /!\\"},
+		},
+		Usage: "Transfer the ownership of an app /!\\",
+		Description: `Transfer the ownership of an app to another user. The new owner must be a collaborator of the app.
+  Example:
+    'scalingo --app my-app transfer --to team-owner@example.com'
+
+  Transfer all the apps you own whose name matches a pattern:
+    'scalingo transfer --pattern "my-project-*" --to team-owner@example.com'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "transfer")
+				return nil
+			}
+
+			var err error
+			if c.IsSet("pattern") {
+				err = apps.TransferMatching(c.Context, c.String("pattern"), c.String("to"), c.Bool("force"))
+			} else {
+				currentApp := detect.CurrentApp(c)
+				err = apps.Transfer(c.Context, currentApp, c.String("to"), c.Bool("force"))
+			}
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "transfer")
+		},
+	}
 )
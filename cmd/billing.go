@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/billing"
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	scalingo "github.com/Scalingo/go-scalingo/v6"
+)
+
+var (
+	invoicesListCommand = cli.Command{
+		Name:     "invoices",
+		Category: "Billing",
+		Usage:    "List the invoices of your account",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "page", Usage: "Page to display", Value: 1},
+			&cli.IntFlag{Name: "per-page", Usage: "Number of invoices to display", Value: 20},
+			&cli.StringFlag{Name: "month", Usage: "Only display the invoices of the given month (YYYY-MM)"},
+		},
+		Description: `List the invoices of your account:
+
+    $ scalingo invoices
+
+  Only display the invoice of a given month:
+
+    $ scalingo invoices --month 2026-09
+
+    # See also commands 'invoice-show' and 'invoice-download'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "invoices")
+				return nil
+			}
+			err := billing.ListInvoices(c.Context, billing.ListInvoicesOpts{
+				PaginationOpts: scalingo.PaginationOpts{
+					Page:    c.Int("page"),
+					PerPage: c.Int("per-page"),
+				},
+				Month: c.String("month"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "invoices")
+		},
+	}
+
+	invoiceShowCommand = cli.Command{
+		Name:      "invoice-show",
+		Category:  "Billing",
+		Usage:     "Show the details of an invoice",
+		ArgsUsage: "invoice-id",
+		Description: `Show the details of an invoice, including its line items:
+
+    $ scalingo invoice-show 7b4d8ae0-39a4-4d6e-a64c-dc1ea6c4f8a2
+
+    # See also commands 'invoices' and 'invoice-download'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "invoice-show")
+				return nil
+			}
+			err := billing.ShowInvoice(c.Context, c.Args().First())
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "invoice-show")
+		},
+	}
+
+	invoiceDownloadCommand = cli.Command{
+		Name:      "invoice-download",
+		Category:  "Billing",
+		Usage:     "Download the PDF of an invoice",
+		ArgsUsage: "invoice-id",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "Output file or directory (- for stdout)"},
+		},
+		Description: `Download the PDF of an invoice. By default the file is saved in the current directory as invoice-<number>.pdf:
+
+    $ scalingo invoice-download 7b4d8ae0-39a4-4d6e-a64c-dc1ea6c4f8a2
+    $ scalingo invoice-download --output ~/invoices/ 7b4d8ae0-39a4-4d6e-a64c-dc1ea6c4f8a2
+
+    # See also commands 'invoices' and 'invoice-show'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "invoice-download")
+				return nil
+			}
+			err := billing.DownloadInvoice(c.Context, c.Args().First(), billing.DownloadInvoiceOpts{
+				Output: c.String("output"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "invoice-download")
+		},
+	}
+
+	billingProfileCommand = cli.Command{
+		Name:     "billing-profile",
+		Category: "Billing",
+		Usage:    "Show your billing profile",
+		Description: `Show the billing profile of your account: company, VAT number and payment method:
+
+    $ scalingo billing-profile
+
+    # See also command 'billing-profile-update'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "billing-profile")
+				return nil
+			}
+			err := billing.ShowProfile(c.Context)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "billing-profile")
+		},
+	}
+
+	billingProfileUpdateCommand = cli.Command{
+		Name:     "billing-profile-update",
+		Category: "Billing",
+		Usage:    "Update your billing profile",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "company", Usage: "Name of the company"},
+			&cli.StringFlag{Name: "vat-number", Usage: "Intra-community VAT number"},
+		},
+		Description: `Update the company name or the VAT number of your billing profile:
+
+    $ scalingo billing-profile-update --company "My Company" --vat-number FR12345678901
+
+    # See also command 'billing-profile'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "billing-profile-update")
+				return nil
+			}
+			params := billing.UpdateProfileParams{}
+			if c.IsSet("company") {
+				company := c.String("company")
+				params.Company = &company
+			}
+			if c.IsSet("vat-number") {
+				vatNumber := c.String("vat-number")
+				params.VATNumber = &vatNumber
+			}
+			err := billing.UpdateProfile(c.Context, params)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "billing-profile-update")
+		},
+	}
+)
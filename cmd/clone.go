@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/manifest"
+	"github.com/Scalingo/cli/utils"
+)
+
+var (
+	cloneCommand = cli.Command{
+		Name:     "clone",
+		Category: "App Management",
+		Usage:    "Create a copy of an app with its configuration",
+		Flags: []cli.Flag{&appFlag,
+			&cli.StringFlag{Name: "name", Usage: "Name of the new app", Required: true},
+			&cli.StringFlag{Name: "region", Usage: "Region of the new app, the region of the source app by default"},
+		},
+		Description: `Create a new app with the same stack, addons (with the same plans), environment variables,
+   routing settings, formation, alerts and autoscalers as the app.
+
+   The domains, the notifiers and the data of the addons are not copied: the API can't restore a
+   backup into another database, use 'backups-download' and the tools of the database to copy the
+   data. The environment variables defined by the addons are not copied, the new app has the ones
+   of its new addons, and the aliases (e.g. DATABASE_URL=$SCALINGO_POSTGRESQL_URL) reference them.
+
+   As the container types are only known after the first deployment, the formation, the alerts
+   and the autoscalers are written to a manifest file to apply with 'scalingo apply' once the new
+   app has been deployed.
+
+   The new app is created in the region given with '--region', the region of the source app is
+   given with the global flag: 'scalingo --region <source region> clone'.
+
+   Example
+     'scalingo --app my-app clone --name my-app-load-test'
+     'scalingo --app my-app clone --name my-app-load-test --region osc-secnum-fr1'
+     'scalingo --region osc-secnum-fr1 --app my-app clone --name my-app-load-test --region osc-fr1'
+
+   # See also 'create', 'export' and 'apply'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "clone")
+				return nil
+			}
+
+			currentApp := detect.CurrentApp(c)
+			utils.CheckForConsent(c.Context, currentApp)
+
+			err := manifest.Clone(c.Context, currentApp, manifest.CloneOpts{
+				Name:   c.String("name"),
+				Region: c.String("region"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "clone")
+		},
+	}
+)
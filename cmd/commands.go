@@ -29,6 +29,7 @@ func (cmds *AppCommands) addCommand(cmd Command) {
 	// I don't really understand the official documentation about this field.
 	// But setting it to true enables the display of the help usage message when `cli.ShowCommandHelp` is called. Without this setting, the call to `cli.ShowCommandHelp` displays a "No help topic for command" error message.
 	cmd.Command.HideHelp = true
+	cmd.Command.Action = projectDefaultsAction(cmd.Command.Action)
 
 	// Global commands are simply added to the list of commands
 	if cmd.Global {
@@ -36,12 +37,34 @@ func (cmds *AppCommands) addCommand(cmd Command) {
 	}
 
 	// Regional commands are modified before being added to the list of commands.
+	action := cmd.Command.Action
+	if hasFlag(cmd.Command, "region") {
+		// The command has its own --region flag (e.g. the region of the app
+		// created by clone), the region to use is given by the global flag
+		cmd.Command.Action = func(c *cli.Context) error {
+			lineage := c.Lineage()
+			return regionalCommandAction(func(*cli.Context) error {
+				return action(c)
+			})(lineage[len(lineage)-1])
+		}
+		return
+	}
 	regionFlag := &cli.StringFlag{Name: "region", Value: "", Usage: "Name of the region to use"}
 	cmd.Command.Flags = append(cmd.Command.Flags, regionFlag)
-	action := cmd.Command.Action
 	cmd.Command.Action = regionalCommandAction(action)
 }
 
+func hasFlag(cmd *cli.Command, name string) bool {
+	for _, flag := range cmd.Flags {
+		for _, flagName := range flag.Names() {
+			if flagName == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func regionalCommandAction(action cli.ActionFunc) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		token := os.Getenv("SCALINGO_API_TOKEN")
@@ -62,6 +85,11 @@ func regionalCommandAction(action cli.ActionFunc) cli.ActionFunc {
 		}
 		currentRegion := regionNameFromFlags(c)
 
+		// Region of the project configuration file
+		if currentRegion == "" {
+			currentRegion = detect.ProjectTargetFromFlags(c).Region
+		}
+
 		// Detecting Region from git remote
 		if currentRegion == "" {
 			currentRegion = detect.GetRegionFromGitRemote(c, &regions)
@@ -114,6 +142,10 @@ var (
 		&CreateCommand,
 		&DestroyCommand,
 		&renameCommand,
+		&transferCommand,
+		&applyCommand,
+		&exportCommand,
+		&cloneCommand,
 		&appsInfoCommand,
 		&openCommand,
 		&dashboardCommand,
@@ -121,12 +153,14 @@ var (
 		// Apps Actions
 		&logsCommand,
 		&logsArchivesCommand,
+		&logsArchivesSyncCommand,
 		&runCommand,
 		&oneOffStopCommand,
 
 		// Apps Process Actions
 		&psCommand,
 		&scaleCommand,
+		&containerSizesCommand,
 		&RestartCommand,
 		&sendSignalCommand,
 
@@ -146,6 +180,7 @@ var (
 		&envGetCommand,
 		&envSetCommand,
 		&envUnsetCommand,
+		&envDiffCommand,
 
 		// Domains
 		&DomainsListCommand,
@@ -211,6 +246,13 @@ var (
 		&databaseBackupsConfig,
 		&databaseEnableFeature,
 		&databaseDisableFeature,
+		&databaseUsersListCommand,
+		&databaseUsersCreateCommand,
+		&databaseUsersUpdatePasswordCommand,
+		&databaseUsersDeleteCommand,
+		&databaseMaintenanceListCommand,
+		&databaseMaintenanceWindowCommand,
+		&databaseMaintenanceUpcomingCommand,
 
 		// Backups
 		&backupsListCommand,
@@ -254,6 +296,11 @@ var (
 
 		// Cron tasks
 		&cronTasksListCommand,
+
+		// Invoices
+		&invoicesListCommand,
+		&invoiceShowCommand,
+		&invoiceDownloadCommand,
 	}
 
 	globalCommands = []*cli.Command{
@@ -267,13 +314,33 @@ var (
 		&integrationsDeleteCommand,
 		&integrationsImportKeysCommand,
 
+		// API tokens
+		&tokensListCommand,
+		&tokensCreateCommand,
+		&tokensShowCommand,
+		&tokensExchangeCommand,
+
+		// Billing profile
+		&billingProfileCommand,
+		&billingProfileUpdateCommand,
+
 		// Sessions
 		&LoginCommand,
 		&LogoutCommand,
+		&profilesCommand,
+		&profileUseCommand,
+		&credentialsMigrateCommand,
+		&pluginsCommand,
+		&aliasSetCommand,
+		&aliasListCommand,
+		&aliasUnsetCommand,
 		&RegionsListCommand,
 		&ConfigCommand,
 		&selfCommand,
 
+		// Logs archives downloaded locally
+		&logsSearchCommand,
+
 		// Version
 		&UpdateCommand,
 
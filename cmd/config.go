@@ -32,7 +32,10 @@ var (
 
 			// If no flag are given, display the current config
 			if regionName == "" {
-				config.Display()
+				err := config.Display()
+				if err != nil {
+					errorQuit(err)
+				}
 			}
 			return nil
 		},
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/apps"
+	"github.com/Scalingo/cli/cmd/autocomplete"
+)
+
+var (
+	containerSizesCommand = cli.Command{
+		Name:     "container-sizes",
+		Category: "App Management",
+		Usage:    "List the available container sizes",
+		Description: `List the container sizes available in the region with their memory, CPU share and price:
+
+		Example:
+			scalingo container-sizes
+
+		# See also 'scale' and 'run'
+`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "container-sizes")
+				return nil
+			}
+			err := apps.ContainerSizes(c.Context)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "container-sizes")
+		},
+	}
+)
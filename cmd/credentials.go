@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/config"
+)
+
+var (
+	credentialsMigrateCommand = cli.Command{
+		Name:     "credentials-migrate",
+		Category: "Global",
+		Usage:    "Move the API tokens from the auth file to the credential helper",
+		Description: `By default the API tokens are stored in clear text in the auth file of the CLI. They can be
+   stored by an external credential helper instead (e.g. pass, a vault agent or an OS keychain),
+   configured with the SCALINGO_CREDENTIAL_HELPER environment variable.
+
+   The credential helper follows the protocol of the git credential helpers: it is called with
+   the 'get', 'store' or 'erase' argument and reads the 'protocol', 'host', 'username' and
+   'password' (the token) attributes as 'key=value' lines on its standard input. The 'get'
+   action writes the 'password' attribute on its standard output. A helper name which is not
+   a path is the suffix of a program of the PATH: 'pass' runs 'scalingo-credential-pass'.
+
+   This command moves the tokens already stored in the auth file of the current profile to the
+   credential helper.
+
+   Example
+     'SCALINGO_CREDENTIAL_HELPER=/usr/lib/git-core/git-credential-libsecret scalingo credentials-migrate'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "credentials-migrate")
+				return nil
+			}
+			err := config.MigrateCredentials()
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "credentials-migrate")
+		},
+	}
+)
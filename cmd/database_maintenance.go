@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/db"
+	"github.com/Scalingo/cli/detect"
+	scalingo "github.com/Scalingo/go-scalingo/v6"
+)
+
+var (
+	databaseMaintenanceListCommand = cli.Command{
+		Name:     "database-maintenance",
+		Category: "Addons",
+		Usage:    "List the maintenances of a database",
+		Flags: []cli.Flag{&appFlag, &addonFlag,
+			&cli.IntFlag{Name: "page", Usage: "Page to display", Value: 1},
+			&cli.IntFlag{Name: "per-page", Usage: "Number of maintenances to display", Value: 20},
+		},
+		Description: `  Display the maintenance window of a database and list its past and upcoming maintenances:
+
+Examples
+ $ scalingo --app myapp --addon addon_uuid database-maintenance
+
+		# See also 'database-maintenance-window' and 'database-maintenance-upcoming'
+`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "database-maintenance")
+				return nil
+			}
+			currentApp := detect.CurrentApp(c)
+			addonName := addonNameFromFlags(c, true)
+			err := db.ListMaintenance(c.Context, currentApp, addonName, scalingo.PaginationOpts{
+				Page:    c.Int("page"),
+				PerPage: c.Int("per-page"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "database-maintenance")
+		},
+	}
+
+	databaseMaintenanceWindowCommand = cli.Command{
+		Name:     "database-maintenance-window",
+		Category: "Addons",
+		Usage:    "Configure the maintenance window of a database",
+		Flags: []cli.Flag{&appFlag, &addonFlag,
+			&cli.StringFlag{Name: "weekday", Usage: "Day of the week the window starts (e.g. tuesday)", Required: true},
+			&cli.StringFlag{Name: "hour", Usage: "Hour of the day the window starts (in local time zone). It is also possible to specify the timezone to use.", Required: true},
+		},
+		Description: `  Configure the day and the hour at which the maintenance window of a database starts. The duration of the window is set by Scalingo:
+
+Examples
+ $ scalingo --app myapp --addon addon_uuid database-maintenance-window --weekday tuesday --hour 3
+ $ scalingo --app myapp --addon addon_uuid database-maintenance-window --weekday sunday --hour "23 Europe/Paris"
+
+		# See also 'database-maintenance'
+`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "database-maintenance-window")
+				return nil
+			}
+			currentApp := detect.CurrentApp(c)
+			addonName := addonNameFromFlags(c, true)
+
+			weekday, err := parseWeekdayFlag(c.String("weekday"))
+			if err != nil {
+				errorQuit(err)
+			}
+			hour, loc, err := parseScheduleAtFlag(c.String("hour"))
+			if err != nil || hour < 0 || hour > 23 {
+				errorQuit(errors.New("fail to parse the hour flag, it should be a number between 0 and 23 optionally followed by a timezone"))
+			}
+
+			err = db.UpdateMaintenanceWindow(c.Context, currentApp, addonName, weekday, hour, loc)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "database-maintenance-window")
+		},
+	}
+
+	databaseMaintenanceUpcomingCommand = cli.Command{
+		Name:     "database-maintenance-upcoming",
+		Category: "Addons",
+		Usage:    "List the upcoming maintenances of the databases of all your apps",
+		Description: `  List the maintenances which are not executed yet, for all the databases of all the apps you have access to:
+
+Examples
+ $ scalingo database-maintenance-upcoming
+
+		# See also 'database-maintenance'
+`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "database-maintenance-upcoming")
+				return nil
+			}
+			err := db.ListUpcomingMaintenance(c.Context)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "database-maintenance-upcoming")
+		},
+	}
+)
+
+func parseWeekdayFlag(flag string) (time.Weekday, error) {
+	if day, err := strconv.Atoi(flag); err == nil && day >= 0 && day <= 6 {
+		return time.Weekday(day), nil
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if strings.EqualFold(flag, name) || strings.EqualFold(flag, name[:3]) {
+			return day, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("unknown weekday '%s'", flag)
+}
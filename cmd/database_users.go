@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/db"
+	"github.com/Scalingo/cli/detect"
+)
+
+var (
+	databaseUsersListCommand = cli.Command{
+		Name:     "database-users",
+		Category: "Addons",
+		Usage:    "List the users of a database",
+		Flags:    []cli.Flag{&appFlag, &addonFlag},
+		Description: `  List the users of a PostgreSQL or MySQL database:
+
+Examples
+ $ scalingo --app myapp --addon addon_uuid database-users
+ $ scalingo --app myapp --addon postgresql database-users
+
+		# See also 'database-users-create', 'database-users-update-password' and 'database-users-delete'
+`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "database-users")
+				return nil
+			}
+			currentApp := detect.CurrentApp(c)
+			addonName := addonNameFromFlags(c, true)
+			err := db.ListUsers(c.Context, currentApp, addonName)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "database-users")
+		},
+	}
+
+	databaseUsersCreateCommand = cli.Command{
+		Name:      "database-users-create",
+		Category:  "Addons",
+		Usage:     "Create a new user on a database",
+		ArgsUsage: "username",
+		Flags: []cli.Flag{&appFlag, &addonFlag,
+			&cli.BoolFlag{Name: "read-only", Usage: "Only grant a read access to the user"},
+			&cli.BoolFlag{Name: "prompt-password", Usage: "Type the password instead of generating a random one"},
+		},
+		Description: `  Create a new user on a PostgreSQL or MySQL database. By default, the user has a read-write access and a random password is generated and displayed once:
+
+Examples
+ $ scalingo --app myapp --addon addon_uuid database-users-create my-user
+ $ scalingo --app myapp --addon addon_uuid database-users-create --read-only metabase
+ $ scalingo --app myapp --addon addon_uuid database-users-create --prompt-password my-user
+
+		# See also 'database-users', 'database-users-update-password' and 'database-users-delete'
+`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "database-users-create")
+				return nil
+			}
+			currentApp := detect.CurrentApp(c)
+			addonName := addonNameFromFlags(c, true)
+			err := db.CreateUser(c.Context, currentApp, addonName, c.Args().First(), db.CreateUserOpts{
+				ReadOnly:       c.Bool("read-only"),
+				PromptPassword: c.Bool("prompt-password"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "database-users-create")
+		},
+	}
+
+	databaseUsersUpdatePasswordCommand = cli.Command{
+		Name:      "database-users-update-password",
+		Category:  "Addons",
+		Usage:     "Change the password of a database user",
+		ArgsUsage: "username",
+		Flags: []cli.Flag{&appFlag, &addonFlag,
+			&cli.BoolFlag{Name: "prompt-password", Usage: "Type the password instead of generating a random one"},
+		},
+		Description: `  Change the password of a PostgreSQL or MySQL database user. By default, a random password is generated and displayed once:
+
+Examples
+ $ scalingo --app myapp --addon addon_uuid database-users-update-password my-user
+ $ scalingo --app myapp --addon addon_uuid database-users-update-password --prompt-password my-user
+
+		# See also 'database-users', 'database-users-create' and 'database-users-delete'
+`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "database-users-update-password")
+				return nil
+			}
+			currentApp := detect.CurrentApp(c)
+			addonName := addonNameFromFlags(c, true)
+			err := db.UpdateUserPassword(c.Context, currentApp, addonName, c.Args().First(), c.Bool("prompt-password"))
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "database-users-update-password")
+		},
+	}
+
+	databaseUsersDeleteCommand = cli.Command{
+		Name:      "database-users-delete",
+		Category:  "Addons",
+		Usage:     "Delete a database user",
+		ArgsUsage: "username",
+		Flags: []cli.Flag{&appFlag, &addonFlag,
+			&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "Delete the user without asking for a confirmation"},
+		},
+		Description: `  Delete a user of a PostgreSQL or MySQL database. A confirmation is asked unless '--yes' is given:
+
+Examples
+ $ scalingo --app myapp --addon addon_uuid database-users-delete my-user
+ $ scalingo --app myapp --addon addon_uuid database-users-delete --yes my-user
+
+		# See also 'database-users', 'database-users-create' and 'database-users-update-password'
+`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "database-users-delete")
+				return nil
+			}
+			currentApp := detect.CurrentApp(c)
+			addonName := addonNameFromFlags(c, true)
+			err := db.DeleteUser(c.Context, currentApp, addonName, c.Args().First(), db.DeleteUserOpts{
+				Yes: c.Bool("yes"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "database-users-delete")
+		},
+	}
+)
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/cmd/autocomplete"
 	"github.com/Scalingo/cli/detect"
@@ -15,25 +17,57 @@ var (
 	envCommand = cli.Command{
 		Name:     "env",
 		Category: "Environment",
-		Flags:    []cli.Flag{&appFlag},
-		Usage:    "Display the environment of your apps",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "reveal", Usage: "Display the secret values in clear text, restricted to the given variables if any"},
+			&cli.StringFlag{Name: "export", Usage: "Export the variables in the given format: dotenv, json or shell"},
+			&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "Path of the file the exported variables are written to, they are written on stdout by default"},
+		},
+		Usage: "Display the environment of your apps",
 		Description: `List all the environment variables:
 
     $ scalingo --app my-app env
 
+  The values which look like credentials (URLs containing a password, variables
+  ending with _TOKEN, _SECRET, _KEY or _PASSWORD) are masked. Display them in
+  clear text with '--reveal', optionally restricted to some variables:
+
+    $ scalingo --app my-app env --reveal
+    $ scalingo --app my-app env --reveal SCALINGO_POSTGRESQL_URL
+
+  Export the environment variables to a dotenv file:
+
+    $ scalingo --app my-app env --export dotenv --file .env.production
+
+  Load the environment variables in the current shell:
+
+    $ eval "$(scalingo --app my-app env --export shell)"
+
     # See also commands 'env-get', 'env-set' and 'env-unset'`,
 
 		Action: func(c *cli.Context) error {
 			currentApp := detect.CurrentApp(c)
 			var err error
-			if c.Args().Len() != 0 {
+			if c.Args().Len() != 0 && !c.Bool("reveal") {
 				cli.ShowCommandHelp(c, "env")
 				return nil
 			}
 
 			utils.CheckForConsent(c.Context, currentApp)
 
-			err = env.Display(c.Context, currentApp)
+			if c.IsSet("export") {
+				err = env.Export(c.Context, currentApp, env.ExportOpts{
+					Format: env.ExportFormat(c.String("export")),
+					File:   c.String("file"),
+				})
+			} else if c.IsSet("file") {
+				cli.ShowCommandHelp(c, "env")
+				return nil
+			} else {
+				err = env.Display(c.Context, currentApp, env.Reveal{
+					All:  c.Bool("reveal") && c.Args().Len() == 0,
+					Keys: c.Args().Slice(),
+				})
+			}
 			if err != nil {
 				errorQuit(err)
 			}
@@ -48,12 +82,19 @@ var (
 	envGetCommand = cli.Command{
 		Name:     "env-get",
 		Category: "Environment",
-		Flags:    []cli.Flag{&appFlag},
-		Usage:    "Get the requested environment variable from your app",
+		Flags: []cli.Flag{&appFlag,
+			&cli.BoolFlag{Name: "reveal", Usage: "Display the value in clear text if it is a secret"},
+		},
+		Usage: "Get the requested environment variable from your app",
 		Description: `Get the value of a specific environment variable:
 
     $ scalingo --app my-app env-get VAR1
 
+  The value is masked if it looks like a credential, use '--reveal' to get it
+  in clear text:
+
+    $ scalingo --app my-app env-get --reveal SCALINGO_POSTGRESQL_URL
+
     # See also commands 'env', 'env-set' and 'env-unset'`,
 
 		Action: func(c *cli.Context) error {
@@ -69,7 +110,7 @@ var (
 			if err != nil {
 				errorQuit(err)
 			}
-			fmt.Println(variableValue)
+			fmt.Println(env.Reveal{All: c.Bool("reveal")}.Value(c.Args().First(), variableValue))
 			return nil
 		},
 		BashComplete: func(c *cli.Context) {
@@ -80,19 +121,37 @@ var (
 	envSetCommand = cli.Command{
 		Name:     "env-set",
 		Category: "Environment",
-		Flags:    []cli.Flag{&appFlag},
-		Usage:    "Set the environment variables of your apps",
+		Flags: []cli.Flag{&appFlag, &appsFlag, &allAppsFlag,
+			&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "Path of a dotenv file containing the variables to set (- for stdin)"},
+		},
+		Usage: "Set the environment variables of your apps",
 		Description: `Set variables:
 
     $ scalingo --app my-app env-set VAR1=VAL1 VAR2=VAL2
 
+  Set all the variables of a dotenv file:
+
+    $ scalingo --app my-app env-set --file .env.production
+
+  The dotenv file supports comments, 'export' prefixes and quoted values
+  which can span multiple lines. Variables given as arguments take precedence
+  over the ones of the file.
+
+  Set a variable on several apps:
+
+    $ scalingo env-set --apps 'shop-*' VAR1=VAL1
+
     # See also commands 'env', 'env-get' and 'env-unset'`,
 
 		Action: func(c *cli.Context) error {
-			currentApp := detect.CurrentApp(c)
 			var err error
-			if c.Args().Len() > 0 {
-				err = env.Add(c.Context, currentApp, c.Args().Slice())
+			if c.String("file") == "-" && isMultiApps(c) {
+				errorQuit(errgo.New("the variables cannot be read from stdin when setting them on several apps"))
+			}
+			if c.Args().Len() > 0 || c.IsSet("file") {
+				err = forEachApp(c, func(ctx context.Context, app string) error {
+					return env.Add(ctx, app, c.Args().Slice(), env.AddOpts{File: c.String("file")})
+				})
 			} else {
 				cli.ShowCommandHelp(c, "env-set")
 				return nil
@@ -110,19 +169,21 @@ var (
 	envUnsetCommand = cli.Command{
 		Name:     "env-unset",
 		Category: "Environment",
-		Flags:    []cli.Flag{&appFlag},
+		Flags:    []cli.Flag{&appFlag, &appsFlag, &allAppsFlag},
 		Usage:    "Unset environment variables of your apps",
 		Description: `Unset variables:
 
     $ scalingo --app my-app env-unset VAR1 VAR2
+    $ scalingo env-unset --apps my-app-front,my-app-api VAR1
 
     # See also commands 'env', 'env-get' and 'env-set'`,
 
 		Action: func(c *cli.Context) error {
-			currentApp := detect.CurrentApp(c)
 			var err error
 			if c.Args().Len() > 0 {
-				err = env.Delete(c.Context, currentApp, c.Args().Slice())
+				err = forEachApp(c, func(ctx context.Context, app string) error {
+					return env.Delete(ctx, app, c.Args().Slice())
+				})
 			} else {
 				cli.ShowCommandHelp(c, "env-unset")
 			}
@@ -136,4 +197,57 @@ var (
 			autocomplete.EnvUnsetAutoComplete(c)
 		},
 	}
+
+	envDiffCommand = cli.Command{
+		Name:     "env-diff",
+		Category: "Environment",
+		Flags: []cli.Flag{&appFlag,
+			&cli.StringFlag{Name: "other-app", Usage: "App the environment is compared to"},
+			&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "Dotenv file the environment is compared to (- for stdin)"},
+			&cli.BoolFlag{Name: "show-values", Usage: "Display the values of the variables instead of masking them"},
+			&cli.StringSliceFlag{Name: "sync", Usage: "Copy the given variable from the other app or file to the app, can be repeated"},
+			&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "Do not ask for confirmation before syncing the variables"},
+		},
+		Usage: "Display the differences between the environment of an app and another app or a dotenv file",
+		Description: `Display the variables which are added, removed or changed in the other app or file compared to the app:
+
+    $ scalingo --app my-app-prod env-diff --other-app my-app-staging
+    $ scalingo --app my-app-prod env-diff --file .env.production
+
+  The values are masked unless '--show-values' is given. The '--sync' flag copies
+  the given variables from the other app or file to the app in one step, the
+  variables missing from the other side are unset:
+
+    $ scalingo --app my-app-prod env-diff --other-app my-app-staging --sync VAR1 --sync VAR2
+
+    # See also commands 'env', 'env-set' and 'env-unset'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 || c.IsSet("other-app") == c.IsSet("file") {
+				cli.ShowCommandHelp(c, "env-diff")
+				return nil
+			}
+
+			currentApp := detect.CurrentApp(c)
+			utils.CheckForConsent(c.Context, currentApp)
+			if c.IsSet("other-app") {
+				utils.CheckForConsent(c.Context, c.String("other-app"))
+			}
+
+			err := env.Diff(c.Context, currentApp, env.DiffOpts{
+				OtherApp:   c.String("other-app"),
+				File:       c.String("file"),
+				ShowValues: c.Bool("show-values"),
+				Sync:       c.StringSlice("sync"),
+				Yes:        c.Bool("yes"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "env-diff")
+		},
+	}
 )
@@ -23,6 +23,19 @@ var (
 	}
 )
 
+// appNameFromFlags returns the app given with the --app flag or the
+// SCALINGO_APP environment variable. Unlike detect.CurrentApp, it returns an
+// empty string if the app is not given.
+func appNameFromFlags(c *cli.Context) string {
+	for _, cliContext := range c.Lineage() {
+		appName := cliContext.String("app")
+		if appName != "" && appName != "<name>" {
+			return appName
+		}
+	}
+	return os.Getenv("SCALINGO_APP")
+}
+
 // exitIfMissing is optional. Set to true to show a message requesting for the --addon flag.
 func addonNameFromFlags(c *cli.Context, exitIfMissing ...bool) string {
 	var addonName string
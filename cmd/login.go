@@ -6,6 +6,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/session"
 )
 
@@ -18,16 +19,25 @@ var (
 			&cli.BoolFlag{Name: "ssh", Usage: "Login with you SSH identity instead of login/password"},
 			&cli.StringFlag{Name: "ssh-identity", Value: "ssh-agent", Usage: "Use a custom SSH key, only compatible if --ssh is set"},
 			&cli.BoolFlag{Name: "password-only", Usage: "Login with login/password without testing SSH connection"},
+			&cli.StringFlag{Name: "profile", Usage: "Name of the authentication profile to login with"},
 		},
 		Usage: "Login to Scalingo platform",
 		Description: `
    Example
-     'scalingo login'`,
+     'scalingo login'
+     'scalingo login --profile client-x'`,
 		Action: func(c *cli.Context) error {
 			if c.Bool("ssh") && c.Bool("password-only") {
 				errorQuit(errors.New("You cannot use both --ssh and --password-only at the same time"))
 			}
 
+			if c.IsSet("profile") {
+				err := config.UseProfile(c.String("profile"))
+				if err != nil {
+					errorQuit(err)
+				}
+			}
+
 			err := session.Login(c.Context, session.LoginOpts{
 				APIToken:     c.String("api-token"),
 				PasswordOnly: c.Bool("password-only"),
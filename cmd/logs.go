@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"strings"
+	"time"
+
 	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/apps"
 	"github.com/Scalingo/cli/cmd/autocomplete"
 	"github.com/Scalingo/cli/db"
 	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/logs"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/cli/utils"
 )
 
@@ -24,38 +30,143 @@ var (
      Get lines with filter:
        'scalingo --app my-app logs -F web'
        'scalingo --app my-app logs -F web-1'
-       'scalingo --app my-app logs --follow -F "worker|clock"'`,
-		Flags: []cli.Flag{&appFlag, &addonFlag,
+       'scalingo --app my-app logs --follow -F "worker|clock"'
+     Structured logs:
+       'scalingo --app my-app logs --json-fields level,msg,request_id'
+       'scalingo --app my-app logs --where level=error --where "msg~timeout"'
+       'scalingo --app my-app logs -F router --where "status>=500"'
+     Logs of a time range, from the logs archives and the recent logs:
+       'scalingo --app my-app logs --since 2026-10-10T14:00 --until 14:30'
+       'scalingo --app my-app logs --since 2h -F web'
+     Router logs statistics (requires the router logs, see 'router-logs'):
+       'scalingo --app my-app logs --router-stats -f'
+       'scalingo --app my-app logs --router-stats --since 14:00 --until 14:30'
+       'scalingo --app my-app logs --router-stats -n 10000 --where "path~^/api"'
+     Logs of several apps and addons merged in a single stream:
+       'scalingo logs -f --apps api,worker,front --addons postgresql'
+       'scalingo logs --apps api,worker --addons api:redis -F web'
+
+   The JSON log lines and the key=value log lines, like the router ones, are parsed. The
+   '--where' conditions are 'field<operator>value' where the operator is =, !=, >, >=, <, <=
+   or ~ (regular expression). The values are compared as numbers when both are numbers.
+   Nested JSON fields are separated with dots, e.g. 'http.status'. A line is displayed if it
+   matches all the conditions.
+
+   The '--since' and '--until' bounds are dates (2026-10-10T14:00, 2026-10-10 14:00:00), times of
+   the day (14:30) or durations before now (2h, 7d). A time of the day given to '--until' is on the
+   date of '--since'. The dates are in the local time zone unless they have one (RFC 3339).
+
+   With '--router-stats', the router log lines are summarized instead of being displayed: requests
+   per minute, status codes, p50/p95/p99 response times, top paths, top client IPs and slowest
+   requests. The summary is refreshed every '--refresh' interval with '--follow', which is only
+   available with the table output. Beyond 10000 requests, the response times percentiles are
+   computed on a random sample of the requests. Beyond 1000 distinct paths or client IPs, the
+   counts of the top ones are approximate.
+
+   With '--apps' or '--addons', the logs of all the apps and addons are merged in timestamp order
+   and each line is prefixed with its source. An addon is either 'app:addon' or 'addon', the
+   addon of each of the apps which have it. Without '--apps', the logs of the current app are
+   merged if it is found, it is only required by the addons given without their app.`,
+		Flags: []cli.Flag{&appFlag, &addonFlag, &appsFlag,
+			&cli.StringFlag{Name: "addons", Usage: "Merge the logs of these addons, comma-separated list of 'addon' or 'app:addon' (e.g. 'postgresql,api:redis')"},
 			&cli.IntFlag{Name: "lines", Aliases: []string{"n"}, Value: 20, Usage: "Number of log lines to dump"},
 			&cli.BoolFlag{Name: "follow", Aliases: []string{"f"}, Usage: "Stream logs of app, (as \"tail -f\")"},
 			&cli.StringFlag{Name: "filter", Aliases: []string{"F"}, Usage: "Filter containers logs that will be displayed"},
+			&cli.StringFlag{Name: "json-fields", Usage: "Comma-separated list of the fields of the structured log lines to display"},
+			&cli.StringSliceFlag{Name: "where", Usage: "Only display the structured log lines matching the condition, e.g. 'level=error' or 'status>=500' (multiple option accepted)"},
+			&cli.BoolFlag{Name: "raw", Usage: "Display the log lines as they are received, without colors"},
+			&cli.StringFlag{Name: "since", Usage: "Display the logs from this date, time or duration before now, e.g. '2026-10-10T14:00' or '2h'"},
+			&cli.StringFlag{Name: "until", Usage: "Display the logs until this date or time, e.g. '14:30'"},
+			&cli.BoolFlag{Name: "router-stats", Usage: "Display a summary of the router logs instead of the log lines"},
+			&cli.DurationFlag{Name: "refresh", Value: 5 * time.Second, Usage: "Refresh interval of the router logs summary with --follow"},
 		},
 		Action: func(c *cli.Context) error {
-			currentApp := detect.CurrentApp(c)
 			if c.Args().Len() != 0 {
 				cli.ShowCommandHelp(c, "logs")
 				return nil
 			}
 
+			displayOpts, err := logsDisplayOptsFromFlags(c)
+			if err != nil {
+				errorQuit(err)
+			}
+
+			if c.String("apps") != "" || c.String("addons") != "" {
+				err = logsSources(c, displayOpts)
+				if err != nil {
+					errorQuit(err)
+				}
+				return nil
+			}
+
+			currentApp := detect.CurrentApp(c)
+
+			timeRange, err := logs.ParseTimeRange(c.String("since"), c.String("until"), time.Now())
+			if err != nil {
+				errorQuit(err)
+			}
+			if !timeRange.Until.IsZero() && c.Bool("f") {
+				errorQuit(errgo.New("--follow can't be used with --until"))
+			}
+
 			addonName := addonNameFromFlags(c)
 
-			var err error
+			filter := c.String("F")
+			var routerStats *logs.RouterStats
+			if c.Bool("router-stats") {
+				if addonName != "" {
+					errorQuit(errgo.New("--router-stats is only available for the app logs"))
+				}
+				if filter != "" && filter != "router" {
+					errorQuit(errgo.New("--router-stats only uses the router logs, it can't be used with --filter"))
+				}
+				// The summary is only written once the logs end, which never
+				// happens with --follow
+				if c.Bool("f") && !output.IsHuman() {
+					errorQuit(errgo.New("--router-stats with --follow is only available with the table output"))
+				}
+				filter = "router"
+				routerStats = logs.NewRouterStats()
+				displayOpts.RouterStats = routerStats
+				err = displayOpts.Validate()
+				if err != nil {
+					errorQuit(err)
+				}
+				if c.Bool("f") {
+					routerStats.Live(c.Duration("refresh"))
+				}
+			}
+
 			if addonName == "" {
 				utils.CheckForConsent(c.Context, currentApp, utils.ConsentTypeContainers)
 
-				err = apps.Logs(c.Context, currentApp, c.Bool("f"), c.Int("n"), c.String("F"))
+				err = apps.Logs(c.Context, currentApp, apps.LogsOpts{
+					Follow:    c.Bool("f"),
+					Count:     c.Int("n"),
+					Filter:    filter,
+					Display:   displayOpts,
+					TimeRange: timeRange,
+				})
 			} else {
 				utils.CheckForConsent(c.Context, currentApp, utils.ConsentTypeDBs)
 
 				err = db.Logs(c.Context, currentApp, addonName, db.LogsOpts{
-					Follow: c.Bool("f"),
-					Count:  c.Int("n"),
+					Follow:    c.Bool("f"),
+					Count:     c.Int("n"),
+					Display:   displayOpts,
+					TimeRange: timeRange,
 				})
 			}
 
 			if err != nil {
 				errorQuit(err)
 			}
+			if routerStats != nil {
+				err = routerStats.Finish()
+				if err != nil {
+					errorQuit(err)
+				}
+			}
 			return nil
 		},
 		BashComplete: func(c *cli.Context) {
@@ -63,3 +174,105 @@ var (
 		},
 	}
 )
+
+// logsSources merges the logs of the apps and addons of the --apps and
+// --addons flags
+func logsSources(c *cli.Context, displayOpts logs.DisplayOpts) error {
+	for _, flag := range []string{"since", "until", "router-stats", "addon"} {
+		if c.IsSet(flag) {
+			return errgo.Newf("--%s can't be used with --apps or --addons", flag)
+		}
+	}
+
+	var addons []string
+	// prefixedApps are the apps of the 'app:addon' entries, in order
+	var prefixedApps []string
+	unprefixed := false
+	for _, addon := range strings.Split(c.String("addons"), ",") {
+		addon = strings.TrimSpace(addon)
+		if addon == "" {
+			continue
+		}
+		addons = append(addons, addon)
+		if split := strings.SplitN(addon, ":", 2); len(split) == 2 {
+			prefixedApps = append(prefixedApps, split[0])
+		} else {
+			unprefixed = true
+		}
+	}
+
+	// Without --apps, the logs of the current app are merged if it is found.
+	// It is only required if an addon is given without its app.
+	var appNames []string
+	if c.String("apps") != "" {
+		var err error
+		appNames, err = apps.SelectApps(c.Context, c.String("apps"), false)
+		if err != nil {
+			return errgo.Notef(err, "fail to select the apps")
+		}
+	} else if currentApp := detect.CurrentAppIfAny(c); currentApp != "" {
+		appNames = []string{currentApp}
+	} else if unprefixed || len(addons) == 0 {
+		appNames = []string{detect.CurrentApp(c)}
+	}
+
+	// The consent is checked once per app: for the containers of the merged
+	// apps and for the databases of the apps whose addons are merged
+	var consentApps []string
+	consentTypes := map[string][]utils.ConsentType{}
+	addConsent := func(app string, consentType utils.ConsentType) {
+		if _, ok := consentTypes[app]; !ok {
+			consentApps = append(consentApps, app)
+		}
+		consentTypes[app] = append(consentTypes[app], consentType)
+	}
+	for _, app := range appNames {
+		addConsent(app, utils.ConsentTypeContainers)
+		if unprefixed {
+			addConsent(app, utils.ConsentTypeDBs)
+		}
+	}
+	for _, app := range prefixedApps {
+		addConsent(app, utils.ConsentTypeDBs)
+	}
+	for _, app := range consentApps {
+		utils.CheckForConsent(c.Context, app, consentTypes[app]...)
+	}
+
+	sources, err := apps.LogsSources(c.Context, appNames, c.String("F"))
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+	if len(addons) > 0 {
+		addonsSources, err := db.AddonsLogsSources(c.Context, appNames, addons)
+		if err != nil {
+			return errgo.Mask(err, errgo.Any)
+		}
+		sources = append(sources, addonsSources...)
+	}
+
+	return logs.Sources(c.Context, sources, logs.SourcesOpts{
+		Count:   c.Int("n"),
+		Follow:  c.Bool("f"),
+		Display: displayOpts,
+	})
+}
+
+func logsDisplayOptsFromFlags(c *cli.Context) (logs.DisplayOpts, error) {
+	where, err := logs.ParseWheres(c.StringSlice("where"))
+	if err != nil {
+		return logs.DisplayOpts{}, err
+	}
+
+	opts := logs.DisplayOpts{
+		Where: where,
+		Raw:   c.Bool("raw"),
+	}
+	for _, field := range strings.Split(c.String("json-fields"), ",") {
+		field = strings.TrimSpace(field)
+		if field != "" {
+			opts.JSONFields = append(opts.JSONFields, field)
+		}
+	}
+	return opts, opts.Validate()
+}
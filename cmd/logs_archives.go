@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/apps"
 	"github.com/Scalingo/cli/cmd/autocomplete"
 	"github.com/Scalingo/cli/db"
 	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/logs"
 	"github.com/Scalingo/cli/utils"
 )
 
@@ -53,4 +58,124 @@ var (
 			autocomplete.CmdFlagsAutoComplete(c, "logs-archives")
 		},
 	}
+
+	logsArchivesSyncCommand = cli.Command{
+		Name:     "logs-archives-sync",
+		Category: "App Management",
+		Usage:    "Download the logs archives of your applications and databases in a directory",
+		Description: `Download in a directory the logs archives of your applications and databases which have not
+   been downloaded yet. The directory contains an index of the time bounds and of the content of
+   the archives, used by 'logs-search' to only read the archives which may contain the matches.
+   An interrupted sync is resumed by the next one.
+
+   Examples:
+     'scalingo --app my-app logs-archives-sync --dir ./logs'
+     'scalingo --app my-app --addon postgresql logs-archives-sync --dir ./logs-postgresql'
+
+   # See also 'logs-search' and 'logs-archives'`,
+		Flags: []cli.Flag{&appFlag, &addonFlag,
+			&cli.StringFlag{Name: "dir", Value: "logs", Usage: "Directory of the logs archives"},
+			&cli.IntFlag{Name: "concurrency", Value: 4, Usage: "Number of archives downloaded in parallel"},
+		},
+		Action: func(c *cli.Context) error {
+			currentApp := detect.CurrentApp(c)
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "logs-archives-sync")
+				return nil
+			}
+
+			opts := logs.SyncArchivesOpts{
+				Dir:         c.String("dir"),
+				Concurrency: c.Int("concurrency"),
+			}
+			addonName := addonNameFromFlags(c)
+
+			var err error
+			if addonName == "" {
+				utils.CheckForConsent(c.Context, currentApp, utils.ConsentTypeContainers)
+
+				err = apps.LogsArchivesSync(c.Context, currentApp, opts)
+			} else {
+				utils.CheckForConsent(c.Context, currentApp, utils.ConsentTypeDBs)
+
+				err = db.LogsArchivesSync(c.Context, currentApp, addonName, opts)
+			}
+
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "logs-archives-sync")
+		},
+	}
+
+	logsSearchCommand = cli.Command{
+		Name:      "logs-search",
+		Category:  "App Management",
+		Usage:     "Search in the logs archives downloaded with 'logs-archives-sync'",
+		ArgsUsage: "pattern",
+		Description: `Search the log lines matching a regular expression in the logs archives downloaded with
+   'logs-archives-sync', without any network access. The lines are displayed in timestamp order.
+   The index of the directory is used to only read the archives of the time range which contain
+   all the words of the pattern (its literal parts of at least 3 characters).
+
+   The '--since' and '--until' bounds are the same as the ones of 'logs'.
+
+   Examples:
+     'scalingo logs-search --since 7d timeout'
+     'scalingo logs-search --dir ./logs -F web --since 2026-10-10T14:00 --until 14:30 "user [0-9]+ not found"'
+     'scalingo logs-search --ignore-case --where "status>=500" error'
+
+   # See also 'logs-archives-sync' and 'logs'`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "dir", Value: "logs", Usage: "Directory of the logs archives"},
+			&cli.StringFlag{Name: "filter", Aliases: []string{"F"}, Usage: "Filter containers logs that will be displayed"},
+			&cli.BoolFlag{Name: "ignore-case", Aliases: []string{"i"}, Usage: "Match the pattern case-insensitively"},
+			&cli.StringFlag{Name: "since", Usage: "Search the logs from this date, time or duration before now, e.g. '2026-10-10T14:00' or '7d'"},
+			&cli.StringFlag{Name: "until", Usage: "Search the logs until this date or time, e.g. '14:30'"},
+			&cli.StringFlag{Name: "json-fields", Usage: "Comma-separated list of the fields of the structured log lines to display"},
+			&cli.StringSliceFlag{Name: "where", Usage: "Only display the structured log lines matching the condition, e.g. 'level=error' or 'status>=500' (multiple option accepted)"},
+			&cli.BoolFlag{Name: "raw", Usage: "Display the log lines as they are stored, without colors"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "logs-search")
+				return nil
+			}
+
+			pattern := c.Args().First()
+			if c.Bool("ignore-case") {
+				pattern = "(?i)" + pattern
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				errorQuit(errgo.Notef(err, "invalid pattern"))
+			}
+			timeRange, err := logs.ParseTimeRange(c.String("since"), c.String("until"), time.Now())
+			if err != nil {
+				errorQuit(err)
+			}
+			displayOpts, err := logsDisplayOptsFromFlags(c)
+			if err != nil {
+				errorQuit(err)
+			}
+
+			err = logs.SearchArchives(c.Context, logs.SearchArchivesOpts{
+				Dir:       c.String("dir"),
+				Pattern:   re,
+				TimeRange: timeRange,
+				Filter:    c.String("filter"),
+				Display:   displayOpts,
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "logs-search")
+		},
+	}
 )
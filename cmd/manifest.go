@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/manifest"
+	"github.com/Scalingo/cli/utils"
+)
+
+var (
+	applyCommand = cli.Command{
+		Name:     "apply",
+		Category: "App Management",
+		Usage:    "Apply the configuration described in a manifest file to an app",
+		Flags: []cli.Flag{&appFlag,
+			&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "Path of the manifest file (- for stdin)", Required: true},
+			&cli.BoolFlag{Name: "prune", Usage: "Delete the resources missing from the sections of the manifest (addons are never deleted)"},
+			&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "Apply the changes without asking for a confirmation"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Only display the changes"},
+		},
+		Description: `Compare the manifest with the current configuration of the app, display the changes and apply them after confirmation.
+
+   The manifest is a YAML file with the following sections: env, formation, addons, domains, routing, notifiers, alerts and autoscalers.
+   A section missing from the manifest is not managed. Use 'scalingo export' to generate the manifest of an existing app.
+   The variables defined by the addons (e.g. SCALINGO_POSTGRESQL_URL) are managed by the addons, they are never deleted by '--prune'.
+
+   Example
+     'scalingo apply -f scalingo.yml'
+     'scalingo --app my-app-staging apply -f scalingo.yml --dry-run'
+
+   # See also 'export'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "apply")
+				return nil
+			}
+			err := manifest.Apply(c.Context, c.String("file"), manifest.ApplyOpts{
+				App:    appNameFromFlags(c),
+				Prune:  c.Bool("prune"),
+				Yes:    c.Bool("yes"),
+				DryRun: c.Bool("dry-run"),
+			})
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "apply")
+		},
+	}
+
+	exportCommand = cli.Command{
+		Name:     "export",
+		Category: "App Management",
+		Usage:    "Generate the manifest file describing the configuration of an app",
+		Flags: []cli.Flag{&appFlag,
+			&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "Path of the manifest file, it is written on stdout by default"},
+		},
+		Description: `Generate the manifest describing the configuration of an app, to be used with 'scalingo apply'.
+   /!\ The manifest contains the values of the environment variables.
+   The variables defined by the addons are not exported and the aliases are exported as references (e.g. $SCALINGO_POSTGRESQL_URL).
+
+   Example
+     'scalingo --app my-app export -f scalingo.yml'
+
+   # See also 'apply'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "export")
+				return nil
+			}
+			currentApp := detect.CurrentApp(c)
+			utils.CheckForConsent(c.Context, currentApp)
+
+			err := manifest.Export(c.Context, currentApp, c.String("file"))
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "export")
+		},
+	}
+)
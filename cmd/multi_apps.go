@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/apps"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+)
+
+var (
+	appsFlag = cli.StringFlag{
+		Name:  "apps",
+		Usage: "Run the command on several apps: comma-separated list of app names or glob patterns (e.g. 'shop-*')",
+	}
+	allAppsFlag = cli.BoolFlag{
+		Name:  "all",
+		Usage: "Run the command on all the apps",
+	}
+)
+
+// isMultiApps returns true if several apps are selected with the --apps or
+// --all flags
+func isMultiApps(c *cli.Context) bool {
+	return c.String("apps") != "" || c.Bool("all")
+}
+
+// forEachApp runs action on the current app or, if the --apps or --all flags
+// are given, in parallel on each selected app before displaying a summary of
+// the results. action must display its output in output.Writer(ctx).
+func forEachApp(c *cli.Context, action func(ctx context.Context, app string) error) error {
+	if !isMultiApps(c) {
+		return action(c.Context, detect.CurrentApp(c))
+	}
+
+	names, err := apps.SelectApps(c.Context, c.String("apps"), c.Bool("all"))
+	if err != nil {
+		return errgo.Notef(err, "fail to select the apps")
+	}
+	if output.IsHuman() {
+		io.Statusf("Running '%s' on %d apps\n", c.Command.Name, len(names))
+	}
+	return apps.RunOnApps(c.Context, names, action)
+}
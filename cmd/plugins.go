@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/detect"
+	"github.com/Scalingo/cli/plugins"
+)
+
+var (
+	pluginsCommand = cli.Command{
+		Name:     "plugins",
+		Category: "Global",
+		Usage:    "List the plugins found in the PATH",
+		Description: `List the plugins found in the PATH. Any executable named 'scalingo-<name>' in the PATH
+   can be run as 'scalingo <name>', unless a built-in command has the same name.
+
+   The plugin gets the arguments following its name. The current app, region, API token and
+   endpoints are given in the environment of the plugin:
+     SCALINGO_APP, SCALINGO_REGION, SCALINGO_API_TOKEN, SCALINGO_API_URL, SCALINGO_DB_URL,
+     SCALINGO_AUTH_URL and SCALINGO_CLI, the path to the scalingo executable
+
+   Example
+     'scalingo plugins'
+     'scalingo --app my-app my-plugin --verbose'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "plugins")
+				return nil
+			}
+			err := plugins.List()
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "plugins")
+		},
+	}
+)
+
+// RunPlugin runs the plugin named after the first argument, if any. It returns
+// false if there is no such plugin. The CLI exits with the exit code of the
+// plugin.
+func RunPlugin(c *cli.Context) bool {
+	if c.Args().Len() == 0 {
+		return false
+	}
+	plugin, ok := plugins.Find(c.Args().First())
+	if !ok {
+		return false
+	}
+
+	regionalCommandAction(func(c *cli.Context) error {
+		err := plugins.Run(c.Context, plugin, c.Args().Tail(), plugins.RunOpts{
+			App: detect.CurrentAppIfAny(c),
+		})
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		if err != nil {
+			errorQuit(err)
+		}
+		return nil
+	})(c)
+	return true
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/config"
+)
+
+var (
+	profilesCommand = cli.Command{
+		Name:     "profiles",
+		Category: "Global",
+		Usage:    "List the authentication profiles",
+		Description: `List the authentication profiles, with the user they are logged in with and their default region.
+
+   Each profile has its own credentials, default region and regions cache. A profile is created by
+   logging in with it. The profile is selected with the '--profile' global flag, the SCALINGO_PROFILE
+   environment variable or, by default, with 'scalingo profile-use'.
+
+   Example
+     'scalingo login --profile client-x'
+     'scalingo profiles'
+     'scalingo --profile client-x apps'
+
+   # See also 'profile-use' and 'login'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "profiles")
+				return nil
+			}
+			err := config.ListProfiles()
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "profiles")
+		},
+	}
+
+	profileUseCommand = cli.Command{
+		Name:      "profile-use",
+		Category:  "Global",
+		Usage:     "Select the authentication profile used by default",
+		ArgsUsage: "profile-name",
+		Description: `Select the authentication profile used when none is given with the '--profile' global flag
+   or the SCALINGO_PROFILE environment variable. The profile without name is 'default'.
+
+   Example
+     'scalingo profile-use client-x'
+     'scalingo profile-use default'
+
+   # See also 'profiles'`,
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "profile-use")
+				return nil
+			}
+			err := config.SetCurrentProfile(c.Args().First())
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "profile-use")
+		},
+	}
+)
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/detect"
+)
+
+// projectDefaultsAction sets the flags of the command which are not given on
+// the command line to their default value from the project configuration file
+func projectDefaultsAction(action cli.ActionFunc) cli.ActionFunc {
+	if action == nil {
+		return nil
+	}
+	return func(c *cli.Context) error {
+		for flag, value := range detect.ProjectDefaults(c.Command.Name) {
+			if c.IsSet(flag) {
+				continue
+			}
+			err := c.Set(flag, value)
+			if err != nil {
+				errorQuit(errgo.Notef(err, "invalid default value of the flag '%s' of '%s' in %s", flag, c.Command.Name, detect.ProjectConfigFileName))
+			}
+		}
+		return action(c)
+	}
+}
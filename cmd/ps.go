@@ -1,11 +1,12 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/apps"
 	"github.com/Scalingo/cli/cmd/autocomplete"
-	"github.com/Scalingo/cli/detect"
 )
 
 var (
@@ -13,18 +14,20 @@ var (
 		Name:     "ps",
 		Category: "App Management",
 		Usage:    "Display your application containers",
-		Flags:    []cli.Flag{&appFlag},
+		Flags:    []cli.Flag{&appFlag, &appsFlag, &allAppsFlag},
 		Description: `Display your application containers
 	Example
-	  'scalingo --app my-app ps'`,
+	  'scalingo --app my-app ps'
+	  'scalingo ps --apps my-app-front,my-app-api'`,
 		Action: func(c *cli.Context) error {
-			currentApp := detect.CurrentApp(c)
 			if c.Args().Len() != 0 {
 				cli.ShowCommandHelp(c, "ps")
 				return nil
 			}
 
-			err := apps.Ps(c.Context, currentApp)
+			err := forEachApp(c, func(ctx context.Context, app string) error {
+				return apps.Ps(ctx, app)
+			})
 			if err != nil {
 				errorQuit(err)
 			}
@@ -1,11 +1,12 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/apps"
 	"github.com/Scalingo/cli/cmd/autocomplete"
-	"github.com/Scalingo/cli/detect"
 )
 
 var (
@@ -13,7 +14,7 @@ var (
 		Name:     "restart",
 		Category: "App Management",
 		Usage:    "Restart processes of your app",
-		Flags: []cli.Flag{&appFlag,
+		Flags: []cli.Flag{&appFlag, &appsFlag, &allAppsFlag,
 			&cli.BoolFlag{Name: "synchronous", Aliases: []string{"s"}, Usage: "Do the restart synchronously"},
 		},
 		Description: `Restart one or several process or your application:
@@ -23,11 +24,15 @@ var (
 		## Restart all the web processes
 	  scalingo --app my-app restart web
 		## Restart a specific container
-	  scalingo --app my-app restart web-1`,
+	  scalingo --app my-app restart web-1
+		## Restart all the apps whose name starts with shop-
+	  scalingo restart --apps 'shop-*'`,
 
 		Action: func(c *cli.Context) error {
-			currentApp := detect.CurrentApp(c)
-			if err := apps.Restart(c.Context, currentApp, c.Bool("s"), c.Args().Slice()); err != nil {
+			err := forEachApp(c, func(ctx context.Context, app string) error {
+				return apps.Restart(ctx, app, c.Bool("s"), c.Args().Slice())
+			})
+			if err != nil {
 				errorQuit(err)
 			}
 			return nil
@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/apps"
 	"github.com/Scalingo/cli/cmd/autocomplete"
-	"github.com/Scalingo/cli/detect"
 )
 
 var (
@@ -13,29 +15,46 @@ var (
 		Name:     "scale",
 		Aliases:  []string{"s"},
 		Category: "App Management",
-		Flags: []cli.Flag{&appFlag,
+		Flags: []cli.Flag{&appFlag, &appsFlag, &allAppsFlag,
 			&cli.BoolFlag{Name: "synchronous", Aliases: []string{"s"}, Usage: "Do the scaling synchronously"},
+			&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "Scale without asking for a confirmation"},
+			&cli.BoolFlag{Name: "dry-run", Usage: "Only display the new formation and its cost"},
 		},
 		Usage: "Scale your application instantly",
 		Description: `Scale your application processes. Without argument, this command lists the container types declared in your application.
+   Before scaling, the new formation and its monthly cost are displayed and a confirmation is asked (unless --yes is given).
    Example
      'scalingo --app my-app scale web:2 worker:1'
      'scalingo --app my-app scale web:1 worker:0'
      'scalingo --app my-app scale web:1:XL'
      'scalingo --app my-app scale web:+1 worker:-1'
+     'scalingo --app my-app scale --dry-run web:20:2XL'
+     'scalingo scale --apps "shop-*" --yes web:2'
+   When scaling several apps with --apps or --all, --yes is required.
      `,
 		Action: func(c *cli.Context) error {
-			currentApp := detect.CurrentApp(c)
-
 			if c.Args().Len() == 0 {
-				err := apps.ContainerTypes(c.Context, currentApp)
+				err := forEachApp(c, func(ctx context.Context, app string) error {
+					return apps.ContainerTypes(ctx, app)
+				})
 				if err != nil {
 					errorQuit(err)
 				}
 				return nil
 			}
 
-			err := apps.Scale(c.Context, currentApp, c.Bool("s"), c.Args().Slice())
+			// The confirmations of the apps scaled in parallel cannot be asked
+			if isMultiApps(c) && !c.Bool("yes") && !c.Bool("dry-run") {
+				errorQuit(errgo.New("--yes is required to scale several apps"))
+			}
+
+			err := forEachApp(c, func(ctx context.Context, app string) error {
+				return apps.Scale(ctx, app, c.Args().Slice(), apps.ScaleOpts{
+					Sync:   c.Bool("s"),
+					Yes:    c.Bool("yes"),
+					DryRun: c.Bool("dry-run"),
+				})
+			})
 			if err != nil {
 				errorQuit(err)
 			}
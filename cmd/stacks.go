@@ -1,10 +1,11 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/Scalingo/cli/cmd/autocomplete"
-	"github.com/Scalingo/cli/detect"
 	"github.com/Scalingo/cli/stacks"
 )
 
@@ -36,22 +37,24 @@ var (
 		Name:     "stacks-set",
 		Category: "Runtime Stacks",
 		Usage:    "Set the runtime stack of an app",
-		Flags:    []cli.Flag{&appFlag},
+		Flags:    []cli.Flag{&appFlag, &appsFlag, &allAppsFlag},
 		Description: `Set the runtime stack of an app (deployment cache will be reset):
 
 		Example:
 			scalingo --app my-app stacks-set scalingo-18
+			scalingo stacks-set --all scalingo-22
 
 		# See also 'stacks'
 `,
 		Action: func(c *cli.Context) error {
-			currentApp := detect.CurrentApp(c)
 			if c.Args().Len() != 1 {
 				cli.ShowCommandHelp(c, "stacks-set")
 				return nil
 			}
 
-			err := stacks.Set(c.Context, currentApp, c.Args().First())
+			err := forEachApp(c, func(ctx context.Context, app string) error {
+				return stacks.Set(ctx, app, c.Args().First())
+			})
 			if err != nil {
 				errorQuit(err)
 			}
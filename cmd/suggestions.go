@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/plugins"
 )
 
 func ShowSuggestions(c *cli.Context) {
@@ -17,12 +20,20 @@ func ShowSuggestions(c *cli.Context) {
 	}
 	endRange := len(cmdName) - startRange
 
+	var names []string
+	for _, cmd := range c.App.Commands {
+		names = append(names, cmd.Name)
+	}
+	for _, plugin := range plugins.Discover(os.Getenv("PATH")) {
+		names = append(names, plugin.Name)
+	}
+
 	if startRange >= 0 {
-		for _, cmd := range c.App.Commands {
-			if strings.HasPrefix(cmd.Name, cmdName[:startRange]) {
-				suggestions = append(suggestions, cmd.Name)
-			} else if strings.HasSuffix(cmd.Name, cmdName[endRange:]) {
-				suggestions = append(suggestions, cmd.Name)
+		for _, name := range names {
+			if strings.HasPrefix(name, cmdName[:startRange]) {
+				suggestions = append(suggestions, name)
+			} else if strings.HasSuffix(name, cmdName[endRange:]) {
+				suggestions = append(suggestions, name)
 			}
 		}
 	}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/Scalingo/cli/cmd/autocomplete"
+	"github.com/Scalingo/cli/tokens"
+)
+
+var (
+	tokensListCommand = cli.Command{
+		Name:     "tokens",
+		Category: "API Tokens",
+		Usage:    "List your API tokens",
+		Description: `List all the API tokens of your account:
+
+    $ scalingo tokens
+
+    # See also commands 'tokens-create', 'tokens-show' and 'tokens-exchange'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "tokens")
+				return nil
+			}
+			err := tokens.List(c.Context)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "tokens")
+		},
+	}
+
+	tokensCreateCommand = cli.Command{
+		Name:     "tokens-create",
+		Category: "API Tokens",
+		Usage:    "Create a new API token",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "name", Aliases: []string{"n"}, Usage: "Name of the API token", Required: true},
+		},
+		Description: `Create a new API token, for instance to be used by a CI service. The value of the token is only displayed once:
+
+    $ scalingo tokens-create --name my-ci
+
+    # See also commands 'tokens', 'tokens-show' and 'tokens-exchange'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "tokens-create")
+				return nil
+			}
+			err := tokens.Create(c.Context, c.String("name"))
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "tokens-create")
+		},
+	}
+
+	tokensShowCommand = cli.Command{
+		Name:      "tokens-show",
+		Category:  "API Tokens",
+		Usage:     "Show the details of an API token",
+		ArgsUsage: "token-id",
+		Description: `Show the details of an API token. Its value is never displayed:
+
+    $ scalingo tokens-show 42
+
+    # See also commands 'tokens', 'tokens-create' and 'tokens-exchange'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 1 {
+				cli.ShowCommandHelp(c, "tokens-show")
+				return nil
+			}
+			err := tokens.Show(c.Context, c.Args().First())
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "tokens-show")
+		},
+	}
+
+	tokensExchangeCommand = cli.Command{
+		Name:     "tokens-exchange",
+		Category: "API Tokens",
+		Usage:    "Print a short-lived bearer token to call the API directly",
+		Description: `Exchange the API token you are logged in with for a short-lived bearer token. Only the bearer token is printed so that it can be used in scripts:
+
+    $ curl -H "Authorization: Bearer $(scalingo tokens-exchange)" https://api.osc-fr1.scalingo.com/v1/apps
+
+    # See also commands 'tokens', 'tokens-create' and 'tokens-show'`,
+
+		Action: func(c *cli.Context) error {
+			if c.Args().Len() != 0 {
+				cli.ShowCommandHelp(c, "tokens-exchange")
+				return nil
+			}
+			err := tokens.Exchange(c.Context)
+			if err != nil {
+				errorQuit(err)
+			}
+			return nil
+		},
+		BashComplete: func(c *cli.Context) {
+			autocomplete.CmdFlagsAutoComplete(c, "tokens-exchange")
+		},
+	}
+)
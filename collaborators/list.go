@@ -2,12 +2,11 @@ package collaborators
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 const (
@@ -29,13 +28,12 @@ func List(ctx context.Context, app string) error {
 		return errgo.Notef(err, "fail to get application information")
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(collaborators)
 	t.SetHeader([]string{"Email", "Username", "Status"})
 
 	t.Append([]string{scapp.Owner.Email, scapp.Owner.Username, CollaboratorOwner})
 	for _, collaborator := range collaborators {
 		t.Append([]string{collaborator.Email, collaborator.Username, string(collaborator.Status)})
 	}
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+	"gopkg.in/errgo.v1"
+)
+
+// aliasAllParameters is replaced by all the arguments following the alias
+const aliasAllParameters = "$@"
+
+var aliasParameterRe = regexp.MustCompile(`\$([1-9][0-9]*)`)
+
+// ExpandAlias replaces the alias used as command in args by its command line.
+// args are the arguments of the CLI, starting with the executable name.
+// flagsWithValue are the names of the global flags expecting a value, to find
+// the command after the global flags.
+//
+// In the command line of the alias, '$1', '$2'… are replaced by the arguments
+// following the alias and '$@' by all of them. The arguments which are not
+// used this way are appended to the command line.
+func ExpandAlias(aliases map[string]string, args []string, flagsWithValue map[string]bool) ([]string, error) {
+	commandIndex := -1
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			commandIndex = i
+			break
+		}
+		if !strings.Contains(arg, "=") && flagsWithValue[strings.TrimLeft(arg, "-")] {
+			i++
+		}
+	}
+	if commandIndex == -1 {
+		return args, nil
+	}
+
+	name := args[commandIndex]
+	commandLine, ok := aliases[name]
+	if !ok {
+		return args, nil
+	}
+
+	expanded, err := expandAliasCommandLine(commandLine, args[commandIndex+1:])
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to expand the alias '%s'", name)
+	}
+
+	result := make([]string, 0, commandIndex+len(expanded))
+	result = append(result, args[:commandIndex]...)
+	return append(result, expanded...), nil
+}
+
+// ValidateAlias checks that the alias can be used as a command
+func ValidateAlias(name, commandLine string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t\n") {
+		return errgo.Newf("invalid alias name '%s'", name)
+	}
+	words, err := shellquote.Split(commandLine)
+	if err != nil {
+		return errgo.Notef(err, "invalid command line")
+	}
+	if len(words) == 0 {
+		return errgo.New("the command line is empty")
+	}
+	return nil
+}
+
+func expandAliasCommandLine(commandLine string, params []string) ([]string, error) {
+	words, err := shellquote.Split(commandLine)
+	if err != nil {
+		return nil, errgo.Notef(err, "invalid command line")
+	}
+
+	usedParams := 0
+	useAllParams := false
+	expanded := make([]string, 0, len(words)+len(params))
+	for _, word := range words {
+		if word == aliasAllParameters {
+			useAllParams = true
+			expanded = append(expanded, params...)
+			continue
+		}
+
+		var missingParam error
+		word = aliasParameterRe.ReplaceAllStringFunc(word, func(param string) string {
+			index, _ := strconv.Atoi(param[1:])
+			if index > usedParams {
+				usedParams = index
+			}
+			if index > len(params) {
+				missingParam = errgo.Newf("argument %s is missing", param)
+				return ""
+			}
+			return params[index-1]
+		})
+		if missingParam != nil {
+			return nil, missingParam
+		}
+		expanded = append(expanded, word)
+	}
+
+	if !useAllParams && usedParams < len(params) {
+		expanded = append(expanded, params[usedParams:]...)
+	}
+	return expanded, nil
+}
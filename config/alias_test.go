@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandAlias(t *testing.T) {
+	aliases := map[string]string{
+		"prod-logs": "--app shop-prod logs -f -n 500 --filter web",
+		"filtered":  "--app shop-prod logs --filter $1",
+		"on-app":    "--app $1 $2",
+		"all":       "--app shop-prod run $@ --size L",
+		"invalid":   "logs 'unterminated",
+	}
+	flagsWithValue := map[string]bool{"app": true, "a": true, "region": true}
+
+	tests := map[string]struct {
+		args          []string
+		expectedArgs  []string
+		expectedError string
+	}{
+		"it should not change a command which is not an alias": {
+			args:         []string{"scalingo", "--app", "prod-logs", "logs"},
+			expectedArgs: []string{"scalingo", "--app", "prod-logs", "logs"},
+		},
+		"it should expand an alias": {
+			args:         []string{"scalingo", "prod-logs"},
+			expectedArgs: []string{"scalingo", "--app", "shop-prod", "logs", "-f", "-n", "500", "--filter", "web"},
+		},
+		"it should keep the global flags and append the extra arguments": {
+			args:         []string{"scalingo", "--region", "osc-fr1", "--json", "prod-logs", "--lines", "10"},
+			expectedArgs: []string{"scalingo", "--region", "osc-fr1", "--json", "--app", "shop-prod", "logs", "-f", "-n", "500", "--filter", "web", "--lines", "10"},
+		},
+		"it should handle the global flags with an equal sign": {
+			args:         []string{"scalingo", "--region=osc-fr1", "prod-logs"},
+			expectedArgs: []string{"scalingo", "--region=osc-fr1", "--app", "shop-prod", "logs", "-f", "-n", "500", "--filter", "web"},
+		},
+		"it should replace the positional parameters": {
+			args:         []string{"scalingo", "on-app", "my-app", "ps", "--json"},
+			expectedArgs: []string{"scalingo", "--app", "my-app", "ps", "--json"},
+		},
+		"it should replace the positional parameters inside an argument": {
+			args:         []string{"scalingo", "filtered", "worker"},
+			expectedArgs: []string{"scalingo", "--app", "shop-prod", "logs", "--filter", "worker"},
+		},
+		"it should replace all the parameters": {
+			args:         []string{"scalingo", "all", "bundle", "exec", "rake"},
+			expectedArgs: []string{"scalingo", "--app", "shop-prod", "run", "bundle", "exec", "rake", "--size", "L"},
+		},
+		"it should return an error if a parameter is missing": {
+			args:          []string{"scalingo", "on-app", "my-app"},
+			expectedError: "fail to expand the alias 'on-app': argument $2 is missing",
+		},
+		"it should return an error if the command line is invalid": {
+			args:          []string{"scalingo", "invalid"},
+			expectedError: "fail to expand the alias 'invalid': invalid command line",
+		},
+		"it should not expand after the end of the flags": {
+			args:         []string{"scalingo", "--", "prod-logs"},
+			expectedArgs: []string{"scalingo", "--", "prod-logs"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			args, err := ExpandAlias(aliases, test.args, flagsWithValue)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
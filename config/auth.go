@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -93,12 +94,21 @@ func (a *CliAuthenticator) StoreAuth(user *scalingo.User, token string) error {
 		return errgo.Notef(err, "fail to get authentication service host")
 	}
 
-	c[authHost] = auth.CredentialsData{
+	creds := auth.CredentialsData{
 		Tokens: &auth.UserToken{
 			Token: token,
 		},
 		User: user,
 	}
+	if helper := newCredentialHelper(C.CredentialHelper); helper != nil {
+		err = helper.store(credentialAttributes{host: authHost, username: user.Username}, token)
+		if err != nil {
+			return errgo.Notef(err, "fail to store the token with the credential helper")
+		}
+		// Only the user is kept in the auth file
+		creds.Tokens = nil
+	}
+	c[authHost] = creds
 
 	authConfig.LastUpdate = time.Now()
 	authConfig.AuthDataVersion = auth.ConfigVersionV21
@@ -109,11 +119,43 @@ func (a *CliAuthenticator) StoreAuth(user *scalingo.User, token string) error {
 	}
 
 	authConfig.AuthConfigPerHost = json.RawMessage(buffer)
-	return writeAuthFile(authConfig)
+	return writeAuthFile(C.AuthFile, authConfig)
 }
 
 func (a *CliAuthenticator) LoadAuth() (*scalingo.User, *auth.UserToken, error) {
-	file, err := os.OpenFile(C.AuthFile, os.O_RDONLY, 0600)
+	user, token, err := a.loadAuth(C.AuthFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if token != nil && token.Token != "" {
+		return user, token, nil
+	}
+
+	// The token is not in the auth file, it has been stored with the credential
+	// helper
+	helper := newCredentialHelper(C.CredentialHelper)
+	if helper == nil {
+		return nil, nil, ErrUnauthenticated
+	}
+	authHost, err := a.authHost()
+	if err != nil {
+		return nil, nil, errgo.Notef(err, "fail to get authentication service host")
+	}
+	helperToken, err := helper.get(credentialAttributes{host: authHost, username: user.Username})
+	if err != nil {
+		return nil, nil, errgo.Notef(err, "fail to get the token from the credential helper")
+	}
+	if helperToken == "" {
+		return nil, nil, ErrUnauthenticated
+	}
+	return user, &auth.UserToken{Token: helperToken}, nil
+}
+
+// loadAuth reads the user and the token of the current authentication host
+// from the auth file. The token is nil if it is stored with the credential
+// helper.
+func (a *CliAuthenticator) loadAuth(authFile string) (*scalingo.User, *auth.UserToken, error) {
+	file, err := os.OpenFile(authFile, os.O_RDONLY, 0600)
 	if os.IsNotExist(err) {
 		return nil, nil, ErrUnauthenticated
 	}
@@ -129,7 +171,7 @@ func (a *CliAuthenticator) LoadAuth() (*scalingo.User, *auth.UserToken, error) {
 	file.Close()
 
 	if authConfig.AuthDataVersion != auth.ConfigVersionV2 && authConfig.AuthDataVersion != auth.ConfigVersionV21 {
-		err = writeAuthFile(&authConfig)
+		err = writeAuthFile(authFile, &authConfig)
 		if err != nil {
 			return nil, nil, errgo.NoteMask(err, "fail to update to authv2", errgo.Any)
 		}
@@ -151,7 +193,7 @@ func (a *CliAuthenticator) LoadAuth() (*scalingo.User, *auth.UserToken, error) {
 			return nil, nil, errgo.Notef(err, "Fail to migrate auth config v2.0 to v2.1")
 		}
 		authConfig.AuthConfigPerHost = json.RawMessage(buffer)
-		err = writeAuthFile(&authConfig)
+		err = writeAuthFile(authFile, &authConfig)
 		if err != nil {
 			return nil, nil, errgo.Notef(err, "Fail to migrate auth config v2.0 to v2.1")
 		}
@@ -185,7 +227,14 @@ func (a *CliAuthenticator) RemoveAuth() error {
 	if err != nil {
 		return errgo.Notef(err, "fail to get authentication service host")
 	}
-	if _, ok := c[authHost]; ok {
+	if creds, ok := c[authHost]; ok {
+		helper := newCredentialHelper(C.CredentialHelper)
+		if helper != nil && creds.User != nil && creds.Tokens == nil {
+			err = helper.erase(credentialAttributes{host: authHost, username: creds.User.Username})
+			if err != nil {
+				return errgo.Notef(err, "fail to erase the token with the credential helper")
+			}
+		}
 		delete(c, authHost)
 	}
 
@@ -195,7 +244,7 @@ func (a *CliAuthenticator) RemoveAuth() error {
 	}
 
 	authConfig.AuthConfigPerHost = json.RawMessage(buffer)
-	return writeAuthFile(authConfig)
+	return writeAuthFile(C.AuthFile, authConfig)
 }
 
 func (a *CliAuthenticator) authHost() (string, error) {
@@ -280,8 +329,13 @@ func tryAuth(ctx context.Context) (*scalingo.User, string, error) {
 	return userInformation, apiToken.Token, nil
 }
 
-func writeAuthFile(authConfig *auth.ConfigData) error {
-	file, err := os.OpenFile(C.AuthFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0700)
+func writeAuthFile(authFile string, authConfig *auth.ConfigData) error {
+	// The directory of a named profile is created on its first login
+	err := os.MkdirAll(filepath.Dir(authFile), 0750)
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+	file, err := os.OpenFile(authFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0700)
 	if err != nil {
 		return errgo.Mask(err, errgo.Any)
 	}
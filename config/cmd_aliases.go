@@ -0,0 +1,73 @@
+package config
+
+import (
+	"sort"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+)
+
+type Alias struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+}
+
+// SetAlias creates or replaces the alias named name
+func SetAlias(name, commandLine string) error {
+	err := ValidateAlias(name, commandLine)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	if C.ConfigFile.Aliases == nil {
+		C.ConfigFile.Aliases = map[string]string{}
+	}
+	C.ConfigFile.Aliases[name] = commandLine
+	err = writeConfigFile()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("'scalingo %s' now runs 'scalingo %s'\n", name, commandLine)
+	return nil
+}
+
+// UnsetAlias removes the alias named name
+func UnsetAlias(name string) error {
+	if _, ok := C.ConfigFile.Aliases[name]; !ok {
+		return errgo.Newf("alias '%s' does not exist", name)
+	}
+
+	delete(C.ConfigFile.Aliases, name)
+	err := writeConfigFile()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("The alias '%s' has been removed\n", name)
+	return nil
+}
+
+// ListAliases displays the aliases with their command line
+func ListAliases() error {
+	aliases := Aliases()
+
+	t := output.NewTable(aliases)
+	t.SetHeader([]string{"Name", "Command"})
+	for _, a := range aliases {
+		t.Append([]string{a.Name, "scalingo " + a.Command})
+	}
+	return t.Render()
+}
+
+// Aliases returns the aliases of the configuration file, sorted by name
+func Aliases() []Alias {
+	aliases := make([]Alias, 0, len(C.ConfigFile.Aliases))
+	for name, commandLine := range C.ConfigFile.Aliases {
+		aliases = append(aliases, Alias{Name: name, Command: commandLine})
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Name < aliases[j].Name
+	})
+	return aliases
+}
@@ -5,17 +5,32 @@ import (
 	"encoding/json"
 	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/output"
 )
 
+// SetRegion sets the default region of the current profile
 func SetRegion(ctx context.Context, regionName string) error {
 	region, err := GetRegion(ctx, C, regionName, GetRegionOpts{})
 	if err != nil {
 		return errgo.Notef(err, "fail to select region")
 	}
 
-	C.ConfigFile.Region = region.Name
+	C.ConfigFile.setProfileRegion(C.Profile, region.Name)
+	return writeConfigFile()
+}
+
+func Display() error {
+	t := output.NewTable(C.ConfigFile)
+	t.SetColWidth(60)
+	t.SetHeader([]string{"Configuration key", "Value"})
+	t.Append([]string{"profile", C.Profile})
+	t.Append([]string{"region", C.ConfigFile.profileRegion(C.Profile)})
+	return t.Render()
+}
+
+func writeConfigFile() error {
 	fd, err := os.OpenFile(C.ConfigFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
 	if err != nil {
 		return errgo.Notef(err, "fail to open config file")
@@ -29,11 +44,3 @@ func SetRegion(ctx context.Context, regionName string) error {
 
 	return nil
 }
-
-func Display() {
-	t := tablewriter.NewWriter(os.Stdout)
-	t.SetColWidth(60)
-	t.SetHeader([]string{"Configuration key", "Value"})
-	t.Append([]string{"region", C.ConfigFile.Region})
-	t.Render()
-}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"sort"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config/auth"
+	"github.com/Scalingo/cli/io"
+)
+
+// MigrateCredentials moves the API tokens of the auth file of the current
+// profile to the credential helper. A token is only removed from the auth file
+// once the credential helper returns it.
+func MigrateCredentials() error {
+	helper := newCredentialHelper(C.CredentialHelper)
+	if helper == nil {
+		return errgo.New("no credential helper configured, set the SCALINGO_CREDENTIAL_HELPER environment variable")
+	}
+
+	authConfig, err := existingAuth()
+	if err != nil {
+		return errgo.Notef(err, "fail to read the auth file")
+	}
+	var configPerHost auth.ConfigPerHostV2
+	err = json.Unmarshal(authConfig.AuthConfigPerHost, &configPerHost)
+	if err != nil {
+		return errgo.Notef(err, "fail to read the auth file")
+	}
+
+	migrated := 0
+	var migrationErr error
+	for _, host := range sortedHosts(configPerHost) {
+		creds := configPerHost[host]
+		if creds.User == nil || creds.Tokens == nil || creds.Tokens.Token == "" {
+			continue
+		}
+		migrationErr = migrateToken(helper, host, creds)
+		if migrationErr != nil {
+			break
+		}
+		creds.Tokens = nil
+		configPerHost[host] = creds
+		migrated++
+		io.Infof("%s (%s): token moved to the credential helper\n", host, creds.User.Username)
+	}
+
+	if migrated > 0 {
+		buffer, err := json.Marshal(&configPerHost)
+		if err != nil {
+			return errgo.Notef(err, "fail to marshal the configuration to JSON")
+		}
+		authConfig.AuthConfigPerHost = json.RawMessage(buffer)
+		err = writeAuthFile(C.AuthFile, authConfig)
+		if err != nil {
+			return errgo.Notef(err, "fail to write the auth file")
+		}
+	}
+	if migrationErr != nil {
+		return errgo.Mask(migrationErr)
+	}
+
+	if migrated == 0 {
+		io.Status("There is no token to migrate in the auth file")
+		return nil
+	}
+	io.Statusf("%d tokens have been moved to the credential helper\n", migrated)
+	return nil
+}
+
+func migrateToken(helper *credentialHelper, host string, creds auth.CredentialsData) error {
+	attrs := credentialAttributes{host: host, username: creds.User.Username}
+	err := helper.store(attrs, creds.Tokens.Token)
+	if err != nil {
+		return errgo.Notef(err, "fail to store the token of %s", host)
+	}
+	token, err := helper.get(attrs)
+	if err != nil {
+		return errgo.Notef(err, "fail to check the token of %s", host)
+	}
+	if token != creds.Tokens.Token {
+		return errgo.Newf("the credential helper does not return the token of %s which has been stored", host)
+	}
+	return nil
+}
+
+func sortedHosts(configPerHost auth.ConfigPerHostV2) []string {
+	hosts := make([]string, 0, len(configPerHost))
+	for host := range configPerHost {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
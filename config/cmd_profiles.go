@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+)
+
+type profile struct {
+	Name    string `json:"name"`
+	User    string `json:"user"`
+	Region  string `json:"region"`
+	Current bool   `json:"current"`
+}
+
+// ListProfiles displays the profiles with the user they are logged in with
+// and their default region
+func ListProfiles() error {
+	profiles, err := profiles()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	t := output.NewTable(profiles)
+	t.SetHeader([]string{"Name", "User", "Region", "Current"})
+	for _, p := range profiles {
+		current := ""
+		if p.Current {
+			current = "*"
+		}
+		t.Append([]string{p.Name, p.User, p.Region, current})
+	}
+	return t.Render()
+}
+
+// SetCurrentProfile sets the profile used when none is given with the
+// --profile flag or the SCALINGO_PROFILE environment variable
+func SetCurrentProfile(name string) error {
+	profiles, err := profiles()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	found := false
+	for _, p := range profiles {
+		found = found || p.Name == name
+	}
+	if !found {
+		return errgo.Newf("profile '%s' does not exist, create it with 'scalingo login --profile %s'", name, name)
+	}
+
+	C.ConfigFile.Profile = name
+	if isDefaultProfile(name) {
+		C.ConfigFile.Profile = ""
+	}
+	err = writeConfigFile()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("The profile '%s' is now used by default\n", name)
+	return nil
+}
+
+// profiles returns the default profile followed by the named profiles, which
+// are the ones with credentials or a default region
+func profiles() ([]profile, error) {
+	names := map[string]bool{}
+	entries, err := os.ReadDir(profilesDir(C.ConfigDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errgo.Notef(err, "fail to list the profiles")
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names[entry.Name()] = true
+		}
+	}
+	for name := range C.ConfigFile.Profiles {
+		names[name] = true
+	}
+	delete(names, DefaultProfile)
+
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	sortedNames = append([]string{DefaultProfile}, sortedNames...)
+
+	currentProfile := C.Profile
+	if currentProfile == "" {
+		currentProfile = DefaultProfile
+	}
+	authenticator := &CliAuthenticator{}
+	profiles := make([]profile, 0, len(sortedNames))
+	for _, name := range sortedNames {
+		authFile := defaultAuthFile
+		if name != DefaultProfile {
+			authFile = filepath.Join(profilesDir(C.ConfigDir), name, "auth")
+		}
+		p := profile{
+			Name:    name,
+			Region:  C.ConfigFile.profileRegion(name),
+			Current: name == currentProfile,
+		}
+		user, _, err := authenticator.loadAuth(authFile)
+		if err == nil && user != nil {
+			p.User = user.Username
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
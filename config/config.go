@@ -18,7 +18,14 @@ import (
 )
 
 type ConfigFile struct {
+	// Region is the default region of the default profile
 	Region string `json:"region"`
+	// Profile is the profile used when none is given with the --profile flag
+	// or the SCALINGO_PROFILE environment variable
+	Profile  string                   `json:"profile,omitempty"`
+	Profiles map[string]ProfileConfig `json:"profiles,omitempty"`
+	// Aliases are the command lines run by 'scalingo <alias>', see ExpandAlias
+	Aliases map[string]string `json:"aliases,omitempty"`
 }
 
 var (
@@ -39,6 +46,14 @@ type Config struct {
 	ScalingoRegion  string `envconfig:"SCALINGO_REGION"`
 	ScalingoSshHost string `envconfig:"SCALINGO_SSH_HOST"`
 
+	// Profile is the name of the profile whose credentials and default region
+	// are used
+	Profile string `envconfig:"SCALINGO_PROFILE"`
+
+	// CredentialHelper is the program storing the API tokens instead of the
+	// auth file
+	CredentialHelper string `envconfig:"SCALINGO_CREDENTIAL_HELPER"`
+
 	// Configuration files
 	ConfigDir      string `envconfig:"CONFIG_DIR"`
 	AuthFile       string `envconfig:"AUTH_FILE"`
@@ -134,9 +149,20 @@ func init() {
 	fd, err := os.Open(C.ConfigFilePath)
 	if err == nil {
 		json.NewDecoder(fd).Decode(&C.ConfigFile)
+		fd.Close()
+	}
+
+	regionOverride = C.ScalingoRegion
+	defaultAuthFile = C.AuthFile
+	defaultRegionsCachePath = C.RegionsCachePath
+	profile := C.Profile
+	if profile == "" {
+		profile = C.ConfigFile.Profile
 	}
-	if C.ScalingoRegion == "" {
-		C.ScalingoRegion = C.ConfigFile.Region
+	err = UseProfile(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Fail to select the profile: %v\n", err)
+		os.Exit(1)
 	}
 }
 
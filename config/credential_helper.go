@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// credentialHelper stores the API tokens with an external program instead of
+// the auth file. It follows the protocol of the git credential helpers: the
+// program is called with the 'get', 'store' or 'erase' action as argument and
+// exchanges 'key=value' lines on its standard input and output. The token is
+// the 'password' attribute, so that the git credential helpers (e.g.
+// git-credential-libsecret) can be used.
+type credentialHelper struct {
+	command []string
+}
+
+// newCredentialHelper returns the credential helper configured with the
+// SCALINGO_CREDENTIAL_HELPER environment variable, or nil if there is none.
+// As with git, a helper name which is not a path is the suffix of a program
+// found in the PATH: 'pass' runs 'scalingo-credential-pass'.
+func newCredentialHelper(spec string) *credentialHelper {
+	command := strings.Fields(spec)
+	if len(command) == 0 {
+		return nil
+	}
+	if !filepath.IsAbs(command[0]) && !strings.ContainsRune(command[0], filepath.Separator) {
+		command[0] = "scalingo-credential-" + command[0]
+	}
+	return &credentialHelper{command: command}
+}
+
+type credentialAttributes struct {
+	host     string
+	username string
+}
+
+// get returns the token stored by the helper, it is empty if there is none
+func (h *credentialHelper) get(attrs credentialAttributes) (string, error) {
+	output, err := h.run("get", attrs, "")
+	if err != nil {
+		return "", errgo.Mask(err)
+	}
+	return output["password"], nil
+}
+
+func (h *credentialHelper) store(attrs credentialAttributes, token string) error {
+	_, err := h.run("store", attrs, token)
+	return errgo.Mask(err)
+}
+
+func (h *credentialHelper) erase(attrs credentialAttributes) error {
+	_, err := h.run("erase", attrs, "")
+	return errgo.Mask(err)
+}
+
+func (h *credentialHelper) run(action string, attrs credentialAttributes, token string) (map[string]string, error) {
+	var input bytes.Buffer
+	fmt.Fprintf(&input, "protocol=https\nhost=%s\nusername=%s\n", attrs.host, attrs.username)
+	if token != "" {
+		fmt.Fprintf(&input, "password=%s\n", token)
+	}
+	input.WriteString("\n")
+
+	debug.Printf("[credential helper] %s %s\n", strings.Join(h.command, " "), action)
+	var output bytes.Buffer
+	cmd := exec.Command(h.command[0], append(h.command[1:], action)...)
+	cmd.Stdin = &input
+	cmd.Stdout = &output
+	// The helper may ask for a passphrase
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to run the credential helper %s %s", h.command[0], action)
+	}
+
+	return parseCredentialAttributes(&output), nil
+}
+
+func parseCredentialAttributes(output *bytes.Buffer) map[string]string {
+	attrs := map[string]string{}
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		key, value, found := strings.Cut(line, "=")
+		if found {
+			attrs[key] = value
+		}
+	}
+	return attrs
+}
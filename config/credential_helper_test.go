@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// testCredentialHelper is a credential helper storing the password in a file
+// next to it
+const testCredentialHelper = `#!/bin/sh
+store="$(dirname "$0")/store"
+case "$1" in
+get) [ -f "$store" ] && cat "$store" ;;
+store) grep '^password=' > "$store" ;;
+erase) rm -f "$store" ;;
+esac
+exit 0
+`
+
+func TestNewCredentialHelper(t *testing.T) {
+	tests := map[string]struct {
+		spec            string
+		expectedCommand []string
+	}{
+		"no helper": {
+			spec: "",
+		},
+		"helper name": {
+			spec:            "pass",
+			expectedCommand: []string{"scalingo-credential-pass"},
+		},
+		"helper path with arguments": {
+			spec:            "/usr/local/bin/vault-helper --mount secret",
+			expectedCommand: []string{"/usr/local/bin/vault-helper", "--mount", "secret"},
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			helper := newCredentialHelper(test.spec)
+			if test.expectedCommand == nil {
+				assert.Nil(t, helper)
+				return
+			}
+			require.NotNil(t, helper)
+			assert.Equal(t, test.expectedCommand, helper.command)
+		})
+	}
+}
+
+func TestCredentialHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test credential helper is a shell script")
+	}
+	dir := t.TempDir()
+	helperPath := filepath.Join(dir, "helper")
+	require.NoError(t, os.WriteFile(helperPath, []byte(testCredentialHelper), 0700))
+	defer func() {
+		C = testConfig
+	}()
+	C = Config{
+		AuthFile:        filepath.Join(dir, "auth"),
+		ScalingoAuthUrl: "https://auth.scalingo.dev",
+	}
+	user := &scalingo.User{Username: "test"}
+	authenticator := &CliAuthenticator{}
+
+	t.Run("the token is stored with the credential helper", func(t *testing.T) {
+		C.CredentialHelper = helperPath
+		require.NoError(t, authenticator.StoreAuth(user, "0123456789"))
+
+		content, err := os.ReadFile(C.AuthFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "0123456789")
+
+		_, token, err := authenticator.LoadAuth()
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", token.Token)
+
+		require.NoError(t, authenticator.RemoveAuth())
+		assert.NoFileExists(t, filepath.Join(dir, "store"))
+	})
+
+	t.Run("the tokens of the auth file are migrated", func(t *testing.T) {
+		C.CredentialHelper = ""
+		require.NoError(t, authenticator.StoreAuth(user, "9876543210"))
+
+		C.CredentialHelper = helperPath
+		require.NoError(t, MigrateCredentials())
+
+		content, err := os.ReadFile(C.AuthFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "9876543210")
+
+		loadedUser, token, err := authenticator.LoadAuth()
+		require.NoError(t, err)
+		assert.Equal(t, "test", loadedUser.Username)
+		assert.Equal(t, "9876543210", token.Token)
+	})
+
+	t.Run("the migration requires a credential helper", func(t *testing.T) {
+		C.CredentialHelper = ""
+		err := MigrateCredentials()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no credential helper configured")
+	})
+}
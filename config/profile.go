@@ -0,0 +1,82 @@
+package config
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/errgo.v1"
+)
+
+// DefaultProfile is the name of the profile used when none is selected. Its
+// files are the ones of the CLI without profile.
+const DefaultProfile = "default"
+
+var (
+	profileNameFormat = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+	// defaultAuthFile and defaultRegionsCachePath are the files of the default
+	// profile
+	defaultAuthFile         string
+	defaultRegionsCachePath string
+	// regionOverride is the region set with the SCALINGO_REGION environment
+	// variable, it takes precedence over the default region of the profile
+	regionOverride string
+)
+
+// ProfileConfig is the configuration specific to a named profile
+type ProfileConfig struct {
+	Region string `json:"region"`
+}
+
+// UseProfile configures the CLI to use the credentials, the default region and
+// the regions cache of the profile. The files of a named profile are stored in
+// the 'profiles/<name>' subdirectories of the configuration and cache
+// directories.
+func UseProfile(name string) error {
+	if name == "" {
+		name = DefaultProfile
+	}
+	if !profileNameFormat.MatchString(name) {
+		return errgo.Newf("invalid profile name '%s', it can only be composed with alphanumerical characters, hyphens and underscores", name)
+	}
+
+	C.Profile = name
+	C.AuthFile = defaultAuthFile
+	C.RegionsCachePath = defaultRegionsCachePath
+	if name != DefaultProfile {
+		C.AuthFile = filepath.Join(profilesDir(C.ConfigDir), name, "auth")
+		C.RegionsCachePath = filepath.Join(profilesDir(C.CacheDir), name, "regions.json")
+	}
+
+	C.ScalingoRegion = regionOverride
+	if C.ScalingoRegion == "" {
+		C.ScalingoRegion = C.ConfigFile.profileRegion(name)
+	}
+	return nil
+}
+
+func profilesDir(dir string) string {
+	return filepath.Join(dir, "profiles")
+}
+
+func isDefaultProfile(name string) bool {
+	return name == "" || name == DefaultProfile
+}
+
+func (f ConfigFile) profileRegion(name string) string {
+	if isDefaultProfile(name) {
+		return f.Region
+	}
+	return f.Profiles[name].Region
+}
+
+func (f *ConfigFile) setProfileRegion(name, region string) {
+	if isDefaultProfile(name) {
+		f.Region = region
+		return
+	}
+	if f.Profiles == nil {
+		f.Profiles = map[string]ProfileConfig{}
+	}
+	f.Profiles[name] = ProfileConfig{Region: region}
+}
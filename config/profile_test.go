@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestUseProfile(t *testing.T) {
+	dir := t.TempDir()
+	defaultAuthFile = filepath.Join(dir, "config", "auth")
+	defaultRegionsCachePath = filepath.Join(dir, "cache", "regions.json")
+	defer func() {
+		C = testConfig
+		defaultAuthFile, defaultRegionsCachePath, regionOverride = "", "", ""
+	}()
+
+	configFile := ConfigFile{
+		Region:   "osc-fr1",
+		Profiles: map[string]ProfileConfig{"client-x": {Region: "osc-secnum-fr1"}},
+	}
+
+	tests := map[string]struct {
+		profile                  string
+		regionOverride           string
+		expectedAuthFile         string
+		expectedRegionsCachePath string
+		expectedRegion           string
+		expectedError            string
+	}{
+		"default profile": {
+			expectedAuthFile:         defaultAuthFile,
+			expectedRegionsCachePath: defaultRegionsCachePath,
+			expectedRegion:           "osc-fr1",
+		},
+		"named profile": {
+			profile:                  "client-x",
+			expectedAuthFile:         filepath.Join(dir, "config", "profiles", "client-x", "auth"),
+			expectedRegionsCachePath: filepath.Join(dir, "cache", "profiles", "client-x", "regions.json"),
+			expectedRegion:           "osc-secnum-fr1",
+		},
+		"named profile without default region": {
+			profile:                  "client-y",
+			expectedAuthFile:         filepath.Join(dir, "config", "profiles", "client-y", "auth"),
+			expectedRegionsCachePath: filepath.Join(dir, "cache", "profiles", "client-y", "regions.json"),
+			expectedRegion:           "",
+		},
+		"the region of the environment takes precedence": {
+			profile:                  "client-x",
+			regionOverride:           "osc-fr2",
+			expectedAuthFile:         filepath.Join(dir, "config", "profiles", "client-x", "auth"),
+			expectedRegionsCachePath: filepath.Join(dir, "cache", "profiles", "client-x", "regions.json"),
+			expectedRegion:           "osc-fr2",
+		},
+		"invalid profile name": {
+			profile:       "../client-x",
+			expectedError: "invalid profile name '../client-x'",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			C = Config{
+				ConfigDir:  filepath.Join(dir, "config"),
+				CacheDir:   filepath.Join(dir, "cache"),
+				ConfigFile: configFile,
+			}
+			regionOverride = test.regionOverride
+
+			err := UseProfile(test.profile)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedAuthFile, C.AuthFile)
+			assert.Equal(t, test.expectedRegionsCachePath, C.RegionsCachePath)
+			assert.Equal(t, test.expectedRegion, C.ScalingoRegion)
+		})
+	}
+}
+
+func TestStoreAuth_NamedProfile(t *testing.T) {
+	dir := t.TempDir()
+	defer func() {
+		C = testConfig
+	}()
+	C = Config{
+		ConfigDir:       dir,
+		ScalingoAuthUrl: "https://auth.scalingo.dev",
+	}
+	require.NoError(t, UseProfile("client-x"))
+
+	authenticator := &CliAuthenticator{}
+	err := authenticator.StoreAuth(&scalingo.User{Username: "client"}, "0123456789")
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, "profiles", "client-x", "auth"))
+	require.NoError(t, err)
+
+	user, token, err := authenticator.LoadAuth()
+	require.NoError(t, err)
+	assert.Equal(t, "client", user.Username)
+	assert.Equal(t, "0123456789", token.Token)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"gopkg.in/errgo.v1"
@@ -97,7 +98,10 @@ func EnsureRegionsCache(ctx context.Context, c Config, opts GetRegionOpts) (Regi
 		return regionsCache, nil
 	}
 
-	fd, err = os.OpenFile(c.RegionsCachePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0750)
+	err = os.MkdirAll(filepath.Dir(c.RegionsCachePath), 0750)
+	if err == nil {
+		fd, err = os.OpenFile(c.RegionsCachePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0750)
+	}
 	if err == nil {
 		json.NewEncoder(fd).Encode(regionsCache)
 		fd.Close()
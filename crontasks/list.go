@@ -2,12 +2,11 @@ package crontasks
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/cli/utils"
 	httpclient "github.com/Scalingo/go-scalingo/v6/http"
 	"github.com/Scalingo/go-utils/errors"
@@ -29,7 +28,7 @@ func List(ctx context.Context, app string) error {
 		// A 404 only means there is no cron task configured on the application. In this case, we want to display an empty table.
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(cronTasks.Jobs)
 	t.SetColWidth(60)
 	t.SetHeader([]string{"Command", "Size", "Last execution", "Next execution"})
 
@@ -41,7 +40,5 @@ func List(ctx context.Context, app string) error {
 
 		t.Append([]string{job.Command, job.Size, lastExecution, job.NextExecutionDate.Format(utils.TimeFormat)})
 	}
-	t.Render()
-
-	return nil
+	return t.Render()
 }
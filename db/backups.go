@@ -2,15 +2,14 @@ package db
 
 import (
 	"context"
-	"os"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
-	"github.com/olekukonko/tablewriter"
 	errgo "gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	scalingo "github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -24,7 +23,7 @@ func ListBackups(ctx context.Context, app, addon string) error {
 		return errgo.Notef(err, "fail to list backups")
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(backups)
 	t.SetHeader([]string{"ID", "Created At", "Size", "Status"})
 
 	for _, backup := range backups {
@@ -35,8 +34,7 @@ func ListBackups(ctx context.Context, app, addon string) error {
 			formatBackupStatus(backup.Status),
 		})
 	}
-	t.Render()
-	return nil
+	return t.Render()
 }
 func formatBackupStatus(status scalingo.BackupStatus) string {
 	switch status {
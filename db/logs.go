@@ -7,13 +7,20 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
 	"github.com/Scalingo/cli/logs"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
+var errAddonNotFound = errgo.New("addon not found")
+
 type LogsOpts struct {
-	Follow bool
-	Count  int
+	Follow  bool
+	Count   int
+	Display logs.DisplayOpts
+	// TimeRange replaces Count to get the logs of a time range, from the logs
+	// archives and the recent logs
+	TimeRange logs.TimeRange
 }
 
 // Logs displays the addon logs.
@@ -39,13 +46,17 @@ func Logs(ctx context.Context, app, addon string, opts LogsOpts) error {
 		return errgo.Notef(err, "fail to get log URL")
 	}
 
-	err = logs.Dump(ctx, url, opts.Count, "")
+	if !opts.TimeRange.IsZero() {
+		err = logs.DumpTimeRange(ctx, url, "", opts.TimeRange, logs.AddonArchivesPager(c, app, addonUUID), opts.Display)
+	} else {
+		err = logs.Dump(ctx, url, opts.Count, "", opts.Display)
+	}
 	if err != nil {
 		return errgo.Notef(err, "fail to dump logs")
 	}
 
 	if opts.Follow {
-		err := logs.Stream(ctx, url, "")
+		err := logs.Stream(ctx, url, "", opts.Display)
 		if err != nil {
 			return errgo.Notef(err, "fail to stream logs")
 		}
@@ -53,6 +64,72 @@ func Logs(ctx context.Context, app, addon string, opts LogsOpts) error {
 	return nil
 }
 
+// AddonsLogsSources returns the logs sources of the addons, to merge them with
+// logs.Sources. An addon is either 'app:addon' or 'addon', which is the addon
+// of each of the apps having it. addon may be an addon UUID or type.
+func AddonsLogsSources(ctx context.Context, apps []string, addons []string) ([]logs.Source, error) {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	var sources []logs.Source
+	for _, addon := range addons {
+		addonApps := apps
+		if split := strings.SplitN(addon, ":", 2); len(split) == 2 {
+			addonApps = []string{split[0]}
+			addon = split[1]
+		}
+		if len(addonApps) == 0 {
+			return nil, errgo.Newf("no app given for the '%s' addon, use 'app:%s'", addon, addon)
+		}
+
+		found := false
+		for _, app := range addonApps {
+			addonUUID := addon
+			if !strings.HasPrefix(addon, "ad-") {
+				addonUUID, err = getAddonUUIDFromType(ctx, c, app, addon)
+				if errgo.Cause(err) == errAddonNotFound && len(addonApps) > 1 {
+					io.Warningf("%s has no '%s' addon, skipping it\n", app, addon)
+					continue
+				}
+				if err != nil {
+					return nil, errgo.Notef(err, "fail to get the %s addon of %s", addon, app)
+				}
+			}
+			url, err := c.AddonLogsURL(ctx, app, addonUUID)
+			if err != nil {
+				return nil, errgo.Notef(err, "fail to get the logs URL of the %s addon of %s", addon, app)
+			}
+			found = true
+			sources = append(sources, logs.Source{Name: app + "/" + addon, LogsURL: url})
+		}
+		if !found {
+			return nil, errgo.Newf("none of the apps has a '%s' addon", addon)
+		}
+	}
+	return sources, nil
+}
+
+// databaseClient returns a Scalingo client and the UUID of the addon which
+// may be given as an addon type (e.g. PostgreSQL)
+func databaseClient(ctx context.Context, app, addon string) (*scalingo.Client, string, error) {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return nil, "", errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	addonUUID := addon
+	// If addon does not contain a UUID, we consider it contains an addon type (e.g. PostgreSQL)
+	if !strings.HasPrefix(addon, "ad-") {
+		addonUUID, err = getAddonUUIDFromType(ctx, c, app, addon)
+		if err != nil {
+			return nil, "", errgo.Notef(err, "fail to get the addon UUID based on its type")
+		}
+	}
+	return c, addonUUID, nil
+}
+
 func getAddonUUIDFromType(ctx context.Context, addonsClient scalingo.AddonsService, app, addonType string) (string, error) {
 	aliases := map[string]string{
 		"psql":     "postgresql",
@@ -82,5 +159,5 @@ func getAddonUUIDFromType(ctx context.Context, addonsClient scalingo.AddonsServi
 		}
 	}
 
-	return "", errgo.Newf("no '%s' addon exists", addonType)
+	return "", errgo.WithCausef(nil, errAddonNotFound, "no '%s' addon exists", addonType)
 }
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/logs"
 )
 
 func LogsArchives(ctx context.Context, app, addon string, page int) error {
@@ -44,3 +45,20 @@ func LogsArchives(ctx context.Context, app, addon string, page int) error {
 
 	return nil
 }
+
+// LogsArchivesSync downloads the logs archives of the addon which are not in
+// the directory yet
+func LogsArchivesSync(ctx context.Context, app, addon string, opts logs.SyncArchivesOpts) error {
+	c, addonUUID, err := databaseClient(ctx, app, addon)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	opts.App = app
+	opts.Addon = addonUUID
+	err = logs.SyncArchives(ctx, logs.AddonArchivesPager(c, app, addonUUID), opts)
+	if err != nil {
+		return errgo.Notef(err, "fail to sync the addon logs archives")
+	}
+	return nil
+}
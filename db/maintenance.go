@@ -0,0 +1,218 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/utils"
+	scalingo "github.com/Scalingo/go-scalingo/v6"
+)
+
+// databaseProviders are the IDs of the addon providers which are databases
+// with a maintenance window
+var databaseProviders = map[string]bool{
+	"postgresql":    true,
+	"mysql":         true,
+	"mongodb":       true,
+	"redis":         true,
+	"influxdb":      true,
+	"elasticsearch": true,
+	"opensearch":    true,
+}
+
+// upcomingMaintenanceStatuses are the statuses of a maintenance which has not
+// been executed yet
+var upcomingMaintenanceStatuses = map[scalingo.MaintenanceStatus]bool{
+	scalingo.MaintenanceStatusScheduled: true,
+	scalingo.MaintenanceStatusNotified:  true,
+	scalingo.MaintenanceStatusQueued:    true,
+	scalingo.MaintenanceStatusRunning:   true,
+}
+
+type upcomingMaintenance struct {
+	App         string                `json:"app"`
+	Addon       string                `json:"addon"`
+	Maintenance *scalingo.Maintenance `json:"maintenance"`
+	NextWindow  time.Time             `json:"next_window"`
+}
+
+// ListMaintenance displays the maintenance window of a database addon and its
+// past and upcoming maintenances.
+// addon may be a addon UUID or an addon type (e.g. PostgreSQL).
+func ListMaintenance(ctx context.Context, app, addon string, paginationOpts scalingo.PaginationOpts) error {
+	c, addonUUID, err := databaseClient(ctx, app, addon)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	maintenances, pagination, err := c.DatabaseListMaintenance(ctx, app, addonUUID, paginationOpts)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the database maintenances")
+	}
+
+	if output.IsHuman() {
+		database, err := c.DatabaseShow(ctx, app, addonUUID)
+		if err != nil {
+			return errgo.Notef(err, "fail to get database information")
+		}
+		window := database.MaintenanceWindow
+		fmt.Printf("%s: %s\n", io.Yellow("Maintenance window"), formatMaintenanceWindow(window))
+		fmt.Printf("%s: %s\n\n", io.Yellow("Next window"), nextMaintenanceWindow(window, time.Now()).Local().Format(utils.TimeFormat))
+	}
+
+	t := output.NewTable(maintenances)
+	t.SetHeader([]string{"ID", "Type", "Status", "Started At", "Ended At"})
+	for _, m := range maintenances {
+		t.Append([]string{m.ID, m.Type, formatMaintenanceStatus(m.Status), formatOptionalTime(m.StartedAt), formatOptionalTime(m.EndedAt)})
+	}
+	err = t.Render()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	fmt.Fprintln(os.Stderr, io.Gray(fmt.Sprintf("Page: %d, Last Page: %d", pagination.CurrentPage, pagination.TotalPages)))
+	return nil
+}
+
+// UpdateMaintenanceWindow changes the maintenance window of a database addon.
+// The weekday and the hour are given in the location loc.
+func UpdateMaintenanceWindow(ctx context.Context, app, addon string, weekday time.Weekday, hour int, loc *time.Location) error {
+	c, addonUUID, err := databaseClient(ctx, app, addon)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	weekdayUTC, hourUTC := maintenanceWindowToUTC(weekday, hour, loc)
+	database, err := c.DatabaseUpdateMaintenanceWindow(ctx, app, addonUUID, scalingo.MaintenanceWindowParams{
+		WeekdayUTC:      &weekdayUTC,
+		StartingHourUTC: &hourUTC,
+	})
+	if err != nil {
+		return errgo.Notef(err, "fail to update the maintenance window")
+	}
+
+	io.Statusf("The maintenance window has been updated: %s\n", formatMaintenanceWindow(database.MaintenanceWindow))
+	return nil
+}
+
+// ListUpcomingMaintenance displays the maintenances which are not executed
+// yet, for all the database addons of all the apps of the account
+func ListUpcomingMaintenance(ctx context.Context) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	apps, err := c.AppsList(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the apps")
+	}
+
+	now := time.Now()
+	upcoming := []upcomingMaintenance{}
+	for _, app := range apps {
+		addons, err := c.AddonsList(ctx, app.Name)
+		if err != nil {
+			io.Warningf("Fail to list the addons of %s: %v\n", app.Name, err)
+			continue
+		}
+		for _, addon := range addons {
+			if addon.AddonProvider == nil || !databaseProviders[addon.AddonProvider.ID] {
+				continue
+			}
+			maintenances, err := addonUpcomingMaintenance(ctx, c, app.Name, addon, now)
+			if err != nil {
+				io.Warningf("Fail to get the maintenances of the %s addon of %s: %v\n", addon.AddonProvider.Name, app.Name, err)
+				continue
+			}
+			upcoming = append(upcoming, maintenances...)
+		}
+	}
+
+	t := output.NewTable(upcoming)
+	t.SetHeader([]string{"App", "Addon", "Type", "Status", "Next Window"})
+	for _, u := range upcoming {
+		t.Append([]string{u.App, u.Addon, u.Maintenance.Type, formatMaintenanceStatus(u.Maintenance.Status), u.NextWindow.Local().Format(utils.TimeFormat)})
+	}
+	return t.Render()
+}
+
+func addonUpcomingMaintenance(ctx context.Context, c *scalingo.Client, app string, addon *scalingo.Addon, now time.Time) ([]upcomingMaintenance, error) {
+	database, err := c.DatabaseShow(ctx, app, addon.ID)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get database information")
+	}
+	maintenances, _, err := c.DatabaseListMaintenance(ctx, app, addon.ID, scalingo.PaginationOpts{Page: 1, PerPage: 20})
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to list the database maintenances")
+	}
+
+	var res []upcomingMaintenance
+	for _, m := range maintenances {
+		if !upcomingMaintenanceStatuses[m.Status] {
+			continue
+		}
+		res = append(res, upcomingMaintenance{
+			App:         app,
+			Addon:       addon.ID,
+			Maintenance: m,
+			NextWindow:  nextMaintenanceWindow(database.MaintenanceWindow, now),
+		})
+	}
+	return res, nil
+}
+
+// maintenanceWindowToUTC converts a weekday and an hour given in the location
+// loc to the UTC weekday and hour expected by the API
+func maintenanceWindowToUTC(weekday time.Weekday, hour int, loc *time.Location) (int, int) {
+	// 2023-01-01 is a Sunday, the first day of the week for the time package
+	t := time.Date(2023, 1, 1+int(weekday), hour, 0, 0, 0, loc).UTC()
+	return int(t.Weekday()), t.Hour()
+}
+
+// nextMaintenanceWindow returns the beginning of the next maintenance window
+// after now. If now is in a maintenance window, the beginning of this window
+// is returned.
+func nextMaintenanceWindow(window scalingo.MaintenanceWindow, now time.Time) time.Time {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := (window.WeekdayUTC - int(now.Weekday()) + 7) % 7
+	next := today.AddDate(0, 0, days).Add(time.Duration(window.StartingHourUTC) * time.Hour)
+	if !next.Add(time.Duration(window.DurationInHour) * time.Hour).After(now) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+func formatMaintenanceWindow(window scalingo.MaintenanceWindow) string {
+	start := time.Date(2023, 1, 1+window.WeekdayUTC, window.StartingHourUTC, 0, 0, 0, time.UTC).Local()
+	return fmt.Sprintf("%ss at %s for %d hours", start.Weekday(), start.Format("15:04 MST"), window.DurationInHour)
+}
+
+func formatMaintenanceStatus(status scalingo.MaintenanceStatus) string {
+	switch status {
+	case scalingo.MaintenanceStatusScheduled, scalingo.MaintenanceStatusNotified, scalingo.MaintenanceStatusQueued:
+		return io.Gray(string(status))
+	case scalingo.MaintenanceStatusRunning:
+		return io.Yellow(string(status))
+	case scalingo.MaintenanceStatusDone:
+		return io.Green(string(status))
+	case scalingo.MaintenanceStatusFailed:
+		return io.BoldRed(string(status))
+	}
+	return string(status)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(utils.TimeFormat)
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	scalingo "github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestMaintenanceWindowToUTC(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("timezone database not available")
+	}
+
+	tests := map[string]struct {
+		weekday            time.Weekday
+		hour               int
+		loc                *time.Location
+		expectedWeekdayUTC int
+		expectedHourUTC    int
+	}{
+		"it should keep the values in UTC": {
+			weekday: time.Tuesday, hour: 3, loc: time.UTC,
+			expectedWeekdayUTC: int(time.Tuesday), expectedHourUTC: 3,
+		},
+		"it should shift the hour": {
+			weekday: time.Tuesday, hour: 3, loc: paris,
+			expectedWeekdayUTC: int(time.Tuesday), expectedHourUTC: 2,
+		},
+		"it should shift the weekday": {
+			weekday: time.Sunday, hour: 0, loc: paris,
+			expectedWeekdayUTC: int(time.Saturday), expectedHourUTC: 23,
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			weekday, hour := maintenanceWindowToUTC(test.weekday, test.hour, test.loc)
+			assert.Equal(t, test.expectedWeekdayUTC, weekday)
+			assert.Equal(t, test.expectedHourUTC, hour)
+		})
+	}
+}
+
+func TestNextMaintenanceWindow(t *testing.T) {
+	// 2026-10-13 is a Tuesday
+	window := scalingo.MaintenanceWindow{WeekdayUTC: int(time.Tuesday), StartingHourUTC: 3, DurationInHour: 8}
+
+	tests := map[string]struct {
+		now      time.Time
+		expected time.Time
+	}{
+		"it should return the window of the current week": {
+			now:      time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, 10, 13, 3, 0, 0, 0, time.UTC),
+		},
+		"it should return the current window if it is in progress": {
+			now:      time.Date(2026, 10, 13, 5, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, 10, 13, 3, 0, 0, 0, time.UTC),
+		},
+		"it should return the window of the next week if it is over": {
+			now:      time.Date(2026, 10, 13, 11, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, test.expected, nextMaintenanceWindow(window, test.now))
+		})
+	}
+}
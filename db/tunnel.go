@@ -59,9 +59,9 @@ func Tunnel(ctx context.Context, opts TunnelOpts) error {
 		return errgo.Newf("no such environment variable: %s", opts.DBEnvVar)
 	}
 
-	dbUrl, err := url.Parse(dbUrlStr)
+	dbUrl, err := parseDatabaseURL(dbUrlStr)
 	if err != nil {
-		return errgo.Notef(err, "invalid database 'URL': %s", dbUrlStr)
+		return errgo.Mask(err)
 	}
 	fmt.Fprintf(os.Stderr, "Building tunnel to %s\n", dbUrl.Host)
 
@@ -233,3 +233,13 @@ func isAddrInUse(err error) bool {
 	}
 	return false
 }
+
+// parseDatabaseURL parses the URL of the database. The parsing error is not
+// wrapped as it contains the raw URL, with the password of the database.
+func parseDatabaseURL(value string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, errgo.New("invalid database URL")
+	}
+	return u, nil
+}
@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/term"
+	scalingo "github.com/Scalingo/go-scalingo/v6"
+)
+
+const (
+	generatedPasswordLength = 24
+	passwordCharset         = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+type CreateUserOpts struct {
+	ReadOnly bool
+	// PromptPassword asks the user to type the password instead of generating
+	// one
+	PromptPassword bool
+}
+
+type DeleteUserOpts struct {
+	// Yes deletes the user without asking for a confirmation
+	Yes bool
+}
+
+// ListUsers displays the users of a database addon.
+// addon may be a addon UUID or an addon type (e.g. PostgreSQL).
+func ListUsers(ctx context.Context, app, addon string) error {
+	c, addonUUID, err := databaseClient(ctx, app, addon)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	users, err := c.DatabaseListUsers(ctx, app, addonUUID)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the database users")
+	}
+
+	t := output.NewTable(users)
+	t.SetHeader([]string{"Username", "Read-Only", "Protected"})
+	for _, user := range users {
+		t.Append([]string{user.Name, fmt.Sprint(user.ReadOnly), fmt.Sprint(user.Protected)})
+	}
+	return t.Render()
+}
+
+func CreateUser(ctx context.Context, app, addon, username string, opts CreateUserOpts) error {
+	c, addonUUID, err := databaseClient(ctx, app, addon)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	password, generated, err := userPassword(opts.PromptPassword)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	user, err := c.DatabaseCreateUser(ctx, app, addonUUID, scalingo.DatabaseCreateUserParam{
+		DatabaseID:           addonUUID,
+		Name:                 username,
+		ReadOnly:             opts.ReadOnly,
+		Password:             password,
+		PasswordConfirmation: password,
+	})
+	if err != nil {
+		return errgo.Notef(err, "fail to create the database user")
+	}
+
+	access := "read-write"
+	if user.ReadOnly {
+		access = "read-only"
+	}
+	io.Statusf("User '%s' has been created with a %s access\n", user.Name, access)
+	if generated {
+		io.Warning("The password is only displayed once, keep it somewhere safe")
+		fmt.Println(password)
+	}
+	return nil
+}
+
+func UpdateUserPassword(ctx context.Context, app, addon, username string, promptPassword bool) error {
+	c, addonUUID, err := databaseClient(ctx, app, addon)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	password, generated, err := userPassword(promptPassword)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	_, err = c.DatabaseUpdateUser(ctx, app, addonUUID, username, scalingo.DatabaseUpdateUserParam{
+		DatabaseID:           addonUUID,
+		Password:             password,
+		PasswordConfirmation: password,
+	})
+	if err != nil {
+		return errgo.Notef(err, "fail to update the password of the database user")
+	}
+
+	io.Statusf("The password of user '%s' has been updated\n", username)
+	if generated {
+		io.Warning("The password is only displayed once, keep it somewhere safe")
+		fmt.Println(password)
+	}
+	return nil
+}
+
+func DeleteUser(ctx context.Context, app, addon, username string, opts DeleteUserOpts) error {
+	c, addonUUID, err := databaseClient(ctx, app, addon)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	if !opts.Yes {
+		io.Infof("The user '%s' will be deleted from the database, the applications using it won't be able to connect anymore. Do you confirm? (y/N)\n", username)
+		var confirm string
+		fmt.Scanln(&confirm)
+		if confirm != "y" && confirm != "Y" {
+			return errgo.New("You didn't confirm, aborting…")
+		}
+	}
+
+	err = c.DatabaseDeleteUser(ctx, app, addonUUID, username)
+	if err != nil {
+		return errgo.Notef(err, "fail to delete the database user")
+	}
+
+	io.Statusf("User '%s' has been deleted\n", username)
+	return nil
+}
+
+// userPassword either prompts the password or generates a random one. The
+// boolean is true if the password has been generated.
+func userPassword(prompt bool) (string, bool, error) {
+	if !prompt {
+		password, err := generatePassword()
+		return password, true, err
+	}
+
+	password, err := term.Password("Password: ")
+	fmt.Println()
+	if err != nil {
+		return "", false, errgo.Mask(err)
+	}
+	confirmation, err := term.Password("Password confirmation: ")
+	fmt.Println()
+	if err != nil {
+		return "", false, errgo.Mask(err)
+	}
+	if password != confirmation {
+		return "", false, errgo.New("the password and its confirmation do not match")
+	}
+	return password, false, nil
+}
+
+func generatePassword() (string, error) {
+	var password strings.Builder
+	max := big.NewInt(int64(len(passwordCharset)))
+	for i := 0; i < generatedPasswordLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", errgo.Notef(err, "fail to generate a password")
+		}
+		password.WriteByte(passwordCharset[n.Int64()])
+	}
+	return password.String(), nil
+}
@@ -6,11 +6,11 @@ import (
 	"os"
 	"time"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/cli/utils"
 	"github.com/Scalingo/go-scalingo/v6"
 )
@@ -26,7 +26,7 @@ func List(ctx context.Context, app string, paginationOpts scalingo.PaginationOpt
 		return errgo.Notef(err, "fail to list the application deployments")
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(deployments)
 	t.SetHeader([]string{"ID", "Date", "Duration", "User", "Git Ref", "Status"})
 
 	for _, deployment := range deployments {
@@ -45,7 +45,10 @@ func List(ctx context.Context, app string, paginationOpts scalingo.PaginationOpt
 			string(deployment.Status),
 		})
 	}
-	t.Render()
+	err = t.Render()
+	if err != nil {
+		return errgo.Mask(err)
+	}
 	fmt.Fprintln(os.Stderr, io.Gray(fmt.Sprintf("Page: %d, Last Page: %d", pagination.CurrentPage, pagination.TotalPages)))
 	return nil
 }
@@ -14,17 +14,41 @@ import (
 )
 
 // CurrentApp returns the app name if it has been found in one of the following:
-// app flag, environment variable "SCALINGO_APP", current Git remote
-// returns an empty string if not found
+// target given with the --target flag, app flag, environment variable
+// "SCALINGO_APP", default target of the project configuration file, current
+// Git remote
+// exits if the app is not found
 func CurrentApp(c *cli.Context) string {
+	appName := CurrentAppIfAny(c)
+	if appName == "" {
+		fmt.Println("Unable to find the application name, please use --app flag.")
+		os.Exit(1)
+	}
+	debug.Println("[detect] App name is", appName)
+
+	return appName
+}
+
+// CurrentAppIfAny looks for the app name the same way as CurrentApp
+// returns an empty string if not found
+func CurrentAppIfAny(c *cli.Context) string {
 	var err error
 
 	appName := extractAppNameFromCommandLine(c)
+	if targetNameFromFlags(c) != "" {
+		appName, err = appNameWithTarget(appName, os.Getenv("SCALINGO_APP"), ProjectTargetFromFlags(c).App)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
 	if appName == "<name>" {
 		appName = ""
 
 		if os.Getenv("SCALINGO_APP") != "" {
 			appName = os.Getenv("SCALINGO_APP")
+		} else if target := ProjectTargetFromFlags(c); target.App != "" {
+			appName = target.App
 		} else if dir, ok := utils.DetectGit(); ok {
 			appName, err = GetAppNameFromGitRemote(dir, RemoteNameFromFlags(c))
 			if err != nil {
@@ -32,14 +56,21 @@ func CurrentApp(c *cli.Context) string {
 			}
 		}
 	}
+	return appName
+}
 
-	if appName == "" {
-		fmt.Println("Unable to find the application name, please use --app flag.")
-		os.Exit(1)
+// appNameWithTarget returns the app name when a target is given with the
+// --target flag. The app of the target has priority over the SCALINGO_APP
+// environment variable, which also sets the app flag. An app flag given
+// explicitly must be the app of the target.
+func appNameWithTarget(flagApp, envApp, targetApp string) (string, error) {
+	if targetApp == "" || flagApp == targetApp {
+		return flagApp, nil
 	}
-	debug.Println("[detect] App name is", appName)
-
-	return appName
+	if flagApp == "<name>" || flagApp == envApp {
+		return targetApp, nil
+	}
+	return "", errgo.Newf("--app %s is not the app of the target (%s), use only one of --app and --target", flagApp, targetApp)
 }
 
 // GetAppNameFromGitRemote searches into the current directory and its parent for a remote
@@ -63,8 +94,17 @@ func GetAppNameFromGitRemote(directory string, remoteName string) (string, error
 	return "", errgo.Newf("[detect] Scalingo Git remote hasn't been found")
 }
 
-// RemoteNameFromFlags returns the remote name specified in command flags
+// RemoteNameFromFlags returns the remote name specified in command flags or,
+// if the flag is not given, in the project configuration file
 func RemoteNameFromFlags(c *cli.Context) string {
+	for _, cliContext := range c.Lineage() {
+		if cliContext.IsSet("remote") {
+			return cliContext.String("remote")
+		}
+	}
+	if remote := ProjectTargetFromFlags(c).Remote; remote != "" {
+		return remote
+	}
 	for _, cliContext := range c.Lineage() {
 		if cliContext.String("remote") != "" {
 			return cliContext.String("remote")
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAppNameFromGitRemote(t *testing.T) {
@@ -32,3 +33,47 @@ func TestGetAppNameFromGitRemote(t *testing.T) {
 		})
 	}
 }
+
+func TestAppNameWithTarget(t *testing.T) {
+	tests := map[string]struct {
+		flagApp         string
+		envApp          string
+		targetApp       string
+		expectedAppName string
+		expectedError   string
+	}{
+		"the app of the target is used without app flag": {
+			flagApp: "<name>", targetApp: "my-api",
+			expectedAppName: "my-api",
+		},
+		"the target has priority over SCALINGO_APP": {
+			flagApp: "my-front", envApp: "my-front", targetApp: "my-api",
+			expectedAppName: "my-api",
+		},
+		"the app flag may be the app of the target": {
+			flagApp: "my-api", targetApp: "my-api",
+			expectedAppName: "my-api",
+		},
+		"a target without app keeps the app flag": {
+			flagApp:         "my-front",
+			expectedAppName: "my-front",
+		},
+		"the app flag and the target disagree": {
+			flagApp: "my-front", targetApp: "my-api",
+			expectedError: "--app my-front is not the app of the target (my-api)",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			appName, err := appNameWithTarget(test.flagApp, test.envApp, test.targetApp)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedAppName, appName)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package detect
+
+import (
+	"bytes"
+	"fmt"
+	stdio "io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/errgo.v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// ProjectConfigFileName is the name of the project configuration file, it is
+// searched in the working directory and its parents
+const ProjectConfigFileName = ".scalingo.yml"
+
+// ProjectTarget is an app of the project with the region and the Git remote
+// used to reach it
+type ProjectTarget struct {
+	App    string `yaml:"app,omitempty"`
+	Region string `yaml:"region,omitempty"`
+	Remote string `yaml:"remote,omitempty"`
+}
+
+// ProjectConfig is the content of the project configuration file:
+//
+//	app: my-app
+//	region: osc-fr1
+//	defaults:
+//	  run:
+//	    size: L
+//	targets:
+//	  api:
+//	    app: my-app-api
+//
+// The top level app, region and remote are used when no target is selected,
+// a target inherits the fields it does not define.
+type ProjectConfig struct {
+	ProjectTarget `yaml:",inline"`
+	// Defaults are the default values of the flags of the commands, by command
+	// name and flag name
+	Defaults map[string]map[string]string `yaml:"defaults,omitempty"`
+	Targets  map[string]ProjectTarget     `yaml:"targets,omitempty"`
+
+	// Path is the path of the file the configuration has been read from
+	Path string `yaml:"-"`
+}
+
+var (
+	projectConfigOnce sync.Once
+	currentProject    *ProjectConfig
+)
+
+// LoadProjectConfig reads the project configuration file of the directory or
+// of its closest parent. It returns nil if there is none.
+func LoadProjectConfig(dir string) (*ProjectConfig, error) {
+	for {
+		path := filepath.Join(dir, ProjectConfigFileName)
+		content, err := os.ReadFile(path)
+		if err == nil {
+			return parseProjectConfig(path, content)
+		}
+		if !os.IsNotExist(err) {
+			return nil, errgo.Notef(err, "fail to read %s", path)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func parseProjectConfig(path string, content []byte) (*ProjectConfig, error) {
+	config := &ProjectConfig{Path: path}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err := decoder.Decode(config)
+	if err != nil && err != stdio.EOF {
+		return nil, errgo.Notef(err, "invalid project configuration file %s", path)
+	}
+	return config, nil
+}
+
+// Target returns the named target merged with the top level configuration,
+// or the top level configuration if name is empty
+func (p *ProjectConfig) Target(name string) (ProjectTarget, error) {
+	if name == "" {
+		return p.ProjectTarget, nil
+	}
+	target, ok := p.Targets[name]
+	if !ok {
+		return ProjectTarget{}, errgo.Newf("unknown target '%s' in %s", name, p.Path)
+	}
+	if target.App == "" {
+		target.App = p.App
+	}
+	if target.Region == "" {
+		target.Region = p.Region
+	}
+	if target.Remote == "" {
+		target.Remote = p.Remote
+	}
+	return target, nil
+}
+
+// currentProjectConfig returns the project configuration of the working
+// directory, the file is only read once
+func currentProjectConfig() *ProjectConfig {
+	projectConfigOnce.Do(func() {
+		dir, err := os.Getwd()
+		if err != nil {
+			debug.Println("[detect] fail to get the working directory:", err)
+			return
+		}
+		currentProject, err = LoadProjectConfig(dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if currentProject != nil {
+			debug.Println("[detect] project configuration file is", currentProject.Path)
+		}
+	})
+	return currentProject
+}
+
+// ProjectTargetFromFlags returns the target of the project configuration file
+// selected with the --target flag, or the top level configuration if no
+// target is selected. It is empty if there is no project configuration file.
+func ProjectTargetFromFlags(c *cli.Context) ProjectTarget {
+	targetName := targetNameFromFlags(c)
+
+	project := currentProjectConfig()
+	if project == nil {
+		if targetName != "" {
+			fmt.Printf("Unable to find the target %s, there is no %s file.\n", targetName, ProjectConfigFileName)
+			os.Exit(1)
+		}
+		return ProjectTarget{}
+	}
+
+	target, err := project.Target(targetName)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return target
+}
+
+// targetNameFromFlags returns the target given with the --target flag
+func targetNameFromFlags(c *cli.Context) string {
+	// --target is a global flag, some commands have their own --target flag
+	lineage := c.Lineage()
+	return lineage[len(lineage)-1].String("target")
+}
+
+// ProjectDefaults returns the default values of the flags of the command
+// defined in the project configuration file
+func ProjectDefaults(command string) map[string]string {
+	project := currentProjectConfig()
+	if project == nil {
+		return nil
+	}
+	return project.Defaults[command]
+}
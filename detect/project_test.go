@@ -0,0 +1,99 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProjectConfig = `app: my-app
+region: osc-fr1
+remote: scalingo
+defaults:
+  run:
+    size: L
+  logs:
+    lines: 100
+targets:
+  api:
+    app: my-app-api
+    remote: scalingo-api
+  secnum:
+    app: my-app-secnum
+    region: osc-secnum-fr1
+`
+
+func TestLoadProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	subDir := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(subDir, 0750))
+
+	t.Run("without project configuration file", func(t *testing.T) {
+		project, err := LoadProjectConfig(subDir)
+		require.NoError(t, err)
+		assert.Nil(t, project)
+	})
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, ProjectConfigFileName), []byte(testProjectConfig), 0600))
+
+	t.Run("the file of a parent directory is found", func(t *testing.T) {
+		project, err := LoadProjectConfig(subDir)
+		require.NoError(t, err)
+		require.NotNil(t, project)
+		assert.Equal(t, filepath.Join(root, ProjectConfigFileName), project.Path)
+		assert.Equal(t, map[string]string{"size": "L"}, project.Defaults["run"])
+		assert.Equal(t, map[string]string{"lines": "100"}, project.Defaults["logs"])
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(subDir, ProjectConfigFileName), []byte("application: my-app\n"), 0600))
+		defer os.Remove(filepath.Join(subDir, ProjectConfigFileName))
+
+		_, err := LoadProjectConfig(subDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid project configuration file")
+	})
+}
+
+func TestProjectConfig_Target(t *testing.T) {
+	project, err := parseProjectConfig(ProjectConfigFileName, []byte(testProjectConfig))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		name           string
+		expectedTarget ProjectTarget
+		expectedError  string
+	}{
+		"no target": {
+			expectedTarget: ProjectTarget{App: "my-app", Region: "osc-fr1", Remote: "scalingo"},
+		},
+		"target inheriting the region": {
+			name:           "api",
+			expectedTarget: ProjectTarget{App: "my-app-api", Region: "osc-fr1", Remote: "scalingo-api"},
+		},
+		"target inheriting the remote": {
+			name:           "secnum",
+			expectedTarget: ProjectTarget{App: "my-app-secnum", Region: "osc-secnum-fr1", Remote: "scalingo"},
+		},
+		"unknown target": {
+			name:          "front",
+			expectedError: "unknown target 'front'",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			target, err := project.Target(test.name)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedTarget, target)
+		})
+	}
+}
@@ -3,12 +3,11 @@ package domains
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	scalingo "github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -30,7 +29,7 @@ func List(ctx context.Context, app string) error {
 		return errgo.Mask(err)
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(domains)
 	t.SetHeader([]string{"Domain", "TLS/SSL"})
 	hasCanonical := false
 
@@ -54,9 +53,12 @@ func List(ctx context.Context, app string) error {
 		}
 		t.Append(row)
 	}
-	t.Render()
+	err = t.Render()
+	if err != nil {
+		return errgo.Mask(err)
+	}
 
-	if hasCanonical {
+	if hasCanonical && output.IsHuman() {
 		fmt.Println("  (*) canonical domain")
 	}
 	return nil
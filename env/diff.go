@@ -0,0 +1,210 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+type DiffStatus string
+
+const (
+	// DiffStatusAdded is the status of a variable only defined on the other side
+	DiffStatusAdded DiffStatus = "added"
+	// DiffStatusRemoved is the status of a variable only defined on the app
+	DiffStatusRemoved DiffStatus = "removed"
+	// DiffStatusChanged is the status of a variable with different values
+	DiffStatusChanged DiffStatus = "changed"
+)
+
+type DiffOpts struct {
+	// OtherApp is the app the environment is compared to
+	OtherApp string
+	// File is the dotenv file the environment is compared to, if OtherApp is
+	// empty
+	File       string
+	ShowValues bool
+	// Sync is the list of variables to copy from the other side to the app.
+	// Variables which are not defined on the other side are unset.
+	Sync []string
+	Yes  bool
+}
+
+type DiffEntry struct {
+	Name       string     `json:"name"`
+	Status     DiffStatus `json:"status"`
+	Value      string     `json:"value,omitempty"`
+	OtherValue string     `json:"other_value,omitempty"`
+}
+
+// Diff displays the variables which differ between the environment of an app
+// and the one of another app or of a dotenv file.
+func Diff(ctx context.Context, app string, opts DiffOpts) error {
+	if (opts.OtherApp == "") == (opts.File == "") {
+		return errgo.New("either another app or a file must be given")
+	}
+
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+	vars, err := c.VariablesListWithoutAlias(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the environment variables of %s", app)
+	}
+
+	var otherVars scalingo.Variables
+	other := opts.File
+	if opts.OtherApp != "" {
+		other = opts.OtherApp
+		otherVars, err = c.VariablesListWithoutAlias(ctx, opts.OtherApp)
+		if err != nil {
+			return errgo.Notef(err, "fail to list the environment variables of %s", opts.OtherApp)
+		}
+	} else {
+		otherVars, err = readDotenvFile(opts.File)
+		if err != nil {
+			return errgo.Notef(err, "fail to read the variables from %s", opts.File)
+		}
+	}
+
+	entries := diffVariables(vars, otherVars)
+	if !opts.ShowValues {
+		entries = maskDiffValues(entries)
+	}
+
+	if rendered, err := output.Data(entries); rendered {
+		if err != nil {
+			return errgo.Mask(err)
+		}
+	} else {
+		displayDiff(app, other, entries)
+	}
+
+	if len(opts.Sync) == 0 {
+		return nil
+	}
+	return syncVariables(ctx, c, app, other, vars, otherVars, entries, opts)
+}
+
+// diffVariables returns the variables which differ between vars and
+// otherVars, sorted by name
+func diffVariables(vars, otherVars scalingo.Variables) []DiffEntry {
+	var entries []DiffEntry
+	for _, v := range vars {
+		otherVar, ok := otherVars.Contains(v.Name)
+		if !ok {
+			entries = append(entries, DiffEntry{Name: v.Name, Status: DiffStatusRemoved, Value: v.Value})
+		} else if otherVar.Value != v.Value {
+			entries = append(entries, DiffEntry{Name: v.Name, Status: DiffStatusChanged, Value: v.Value, OtherValue: otherVar.Value})
+		}
+	}
+	for _, otherVar := range otherVars {
+		if _, ok := vars.Contains(otherVar.Name); !ok {
+			entries = append(entries, DiffEntry{Name: otherVar.Name, Status: DiffStatusAdded, OtherValue: otherVar.Value})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+func maskDiffValues(entries []DiffEntry) []DiffEntry {
+	masked := make([]DiffEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Value != "" {
+			e.Value = maskedValue
+		}
+		if e.OtherValue != "" {
+			e.OtherValue = maskedValue
+		}
+		masked = append(masked, e)
+	}
+	return masked
+}
+
+func displayDiff(app, other string, entries []DiffEntry) {
+	if len(entries) == 0 {
+		io.Statusf("The environment of %s is identical to %s\n", app, other)
+		return
+	}
+
+	fmt.Printf("Differences between the environment of %s and %s:\n\n", app, other)
+	counts := map[DiffStatus]int{}
+	for _, e := range entries {
+		counts[e.Status]++
+		switch e.Status {
+		case DiffStatusAdded:
+			fmt.Println(io.Green(fmt.Sprintf("  + %s=%s", e.Name, e.OtherValue)))
+		case DiffStatusChanged:
+			fmt.Println(io.Yellow(fmt.Sprintf("  ~ %s: %s => %s", e.Name, e.Value, e.OtherValue)))
+		case DiffStatusRemoved:
+			fmt.Println(io.BoldRed(fmt.Sprintf("  - %s=%s", e.Name, e.Value)))
+		}
+	}
+	fmt.Printf("\n%d added, %d changed, %d removed.\n", counts[DiffStatusAdded], counts[DiffStatusChanged], counts[DiffStatusRemoved])
+}
+
+// syncVariables copies the selected variables from the other side to the app
+// in one request, the selected variables missing on the other side are unset
+func syncVariables(ctx context.Context, c *scalingo.Client, app, other string, vars, otherVars scalingo.Variables, entries []DiffEntry, opts DiffOpts) error {
+	var toSet, toUnset scalingo.Variables
+	for _, name := range opts.Sync {
+		var entry *DiffEntry
+		for i := range entries {
+			if entries[i].Name == name {
+				entry = &entries[i]
+				break
+			}
+		}
+		if entry == nil {
+			return errgo.Newf("%s is not different between %s and %s", name, app, other)
+		}
+
+		if entry.Status == DiffStatusRemoved {
+			v, _ := vars.Contains(name)
+			toUnset = append(toUnset, v)
+		} else {
+			v, _ := otherVars.Contains(name)
+			toSet = append(toSet, &scalingo.Variable{Name: v.Name, Value: v.Value})
+		}
+	}
+
+	if !opts.Yes {
+		io.Infof("%d variables will be set and %d unset on %s. Do you confirm? (y/N)\n", len(toSet), len(toUnset), app)
+		var confirm string
+		fmt.Scanln(&confirm)
+		if confirm != "y" && confirm != "Y" {
+			return errgo.New("You didn't confirm, aborting…")
+		}
+	}
+
+	if len(toSet) > 0 {
+		_, _, err := c.VariableMultipleSet(ctx, app, toSet)
+		if err != nil {
+			return errgo.Notef(err, "fail to set the variables")
+		}
+		for _, v := range toSet {
+			fmt.Printf("%s has been set.\n", v.Name)
+		}
+	}
+	for _, v := range toUnset {
+		err := c.VariableUnset(ctx, app, v.ID)
+		if err != nil {
+			return errgo.Notef(err, "fail to unset %s", v.Name)
+		}
+		fmt.Printf("%s has been unset.\n", v.Name)
+	}
+	fmt.Println("\nRestart your containers to apply these environment changes on your application:")
+	fmt.Printf("scalingo --app %s restart\n", app)
+	return nil
+}
@@ -0,0 +1,61 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestDiffVariables(t *testing.T) {
+	tests := map[string]struct {
+		vars            scalingo.Variables
+		otherVars       scalingo.Variables
+		expectedEntries []DiffEntry
+	}{
+		"identical environments": {
+			vars:      scalingo.Variables{{Name: "VAR1", Value: "value1"}},
+			otherVars: scalingo.Variables{{Name: "VAR1", Value: "value1"}},
+		},
+		"added, changed and removed variables are sorted by name": {
+			vars: scalingo.Variables{
+				{Name: "VAR3", Value: "value3"},
+				{Name: "VAR2", Value: "value2"},
+				{Name: "VAR4", Value: "value4"},
+			},
+			otherVars: scalingo.Variables{
+				{Name: "VAR1", Value: "value1"},
+				{Name: "VAR2", Value: "other2"},
+				{Name: "VAR4", Value: "value4"},
+			},
+			expectedEntries: []DiffEntry{
+				{Name: "VAR1", Status: DiffStatusAdded, OtherValue: "value1"},
+				{Name: "VAR2", Status: DiffStatusChanged, Value: "value2", OtherValue: "other2"},
+				{Name: "VAR3", Status: DiffStatusRemoved, Value: "value3"},
+			},
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, test.expectedEntries, diffVariables(test.vars, test.otherVars))
+		})
+	}
+}
+
+func TestMaskDiffValues(t *testing.T) {
+	entries := []DiffEntry{
+		{Name: "VAR1", Status: DiffStatusAdded, OtherValue: "value1"},
+		{Name: "VAR2", Status: DiffStatusChanged, Value: "", OtherValue: "other2"},
+	}
+
+	masked := maskDiffValues(entries)
+
+	assert.Equal(t, []DiffEntry{
+		{Name: "VAR1", Status: DiffStatusAdded, OtherValue: maskedValue},
+		{Name: "VAR2", Status: DiffStatusChanged, Value: "", OtherValue: maskedValue},
+	}, masked)
+	// The original entries are kept to sync the variables
+	assert.Equal(t, "value1", entries[0].OtherValue)
+}
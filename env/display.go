@@ -8,9 +8,13 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
 )
 
-func Display(ctx context.Context, app string) error {
+// Display prints the environment variables of an app, the secret values are
+// masked unless they are revealed.
+func Display(ctx context.Context, app string, reveal Reveal) error {
 	c, err := config.ScalingoClient(ctx)
 	if err != nil {
 		return errgo.Notef(err, "fail to get Scalingo client")
@@ -20,6 +24,18 @@ func Display(ctx context.Context, app string) error {
 		return errgo.Notef(err, "fail to list the environment variables")
 	}
 
+	masked := make(scalingo.Variables, 0, len(vars))
+	for _, v := range vars {
+		maskedVar := *v
+		maskedVar.Value = reveal.Value(v.Name, v.Value)
+		masked = append(masked, &maskedVar)
+	}
+	vars = masked
+
+	if rendered, err := output.Data(vars); rendered {
+		return errgo.Mask(err)
+	}
+
 	for _, v := range vars {
 		fmt.Printf("%s=%s\n", v.Name, v.Value)
 	}
@@ -0,0 +1,207 @@
+package env
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatDotenv ExportFormat = "dotenv"
+	ExportFormatJSON   ExportFormat = "json"
+	ExportFormatShell  ExportFormat = "shell"
+)
+
+func (f ExportFormat) validate() error {
+	switch f {
+	case ExportFormatDotenv, ExportFormatJSON, ExportFormatShell:
+		return nil
+	}
+	return errgo.Newf("unknown export format '%s', accepted values are: dotenv, json, shell", f)
+}
+
+var (
+	// unquotedValueFormat matches the values which can be written without
+	// quotes in a dotenv file
+	unquotedValueFormat = regexp.MustCompile(`^[a-zA-Z0-9_./:@%+,-]*$`)
+)
+
+// ParseDotenv parses the content of a dotenv file. It supports comments,
+// `export` prefixes, single quoted values (taken literally) and double quoted
+// values (with \n, \t, \" and \\ escapes). Quoted values may span multiple
+// lines. Empty values are allowed.
+func ParseDotenv(r stdio.Reader) (scalingo.Variables, error) {
+	var variables scalingo.Variables
+	indexes := map[string]int{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, errgo.Newf("line %d: %s", lineNumber, setInvalidSyntaxError)
+		}
+		if !nameFormat.MatchString(name) {
+			return nil, errgo.Newf("line %d: %s", lineNumber, errInvalidNameFormat)
+		}
+		value = strings.TrimSpace(value)
+
+		if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, `'`) {
+			quote := value[:1]
+			startLine := lineNumber
+			// Read the following lines until the closing quote
+			for !hasClosingQuote(value, quote) {
+				if !scanner.Scan() {
+					return nil, errgo.Newf("line %d: missing closing quote for %s", startLine, name)
+				}
+				lineNumber++
+				value += "\n" + scanner.Text()
+			}
+			value = unquote(value, quote)
+		} else if i := strings.Index(value, " #"); i != -1 {
+			// Inline comment
+			value = strings.TrimSpace(value[:i])
+		}
+
+		if i, ok := indexes[name]; ok {
+			variables[i].Value = value
+			continue
+		}
+		indexes[name] = len(variables)
+		variables = append(variables, &scalingo.Variable{Name: name, Value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errgo.Notef(err, "fail to read the dotenv file")
+	}
+	return variables, nil
+}
+
+// readDotenvFile parses the dotenv file at path, "-" reads it from stdin
+func readDotenvFile(path string) (scalingo.Variables, error) {
+	if path == "-" {
+		return ParseDotenv(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to open file")
+	}
+	defer f.Close()
+	return ParseDotenv(f)
+}
+
+// hasClosingQuote returns true if the quoted value, starting with its opening
+// quote, contains the closing quote
+func hasClosingQuote(value, quote string) bool {
+	escaped := false
+	for i := 1; i < len(value); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == `"` && value[i] == '\\':
+			escaped = true
+		case value[i:i+1] == quote:
+			return true
+		}
+	}
+	return false
+}
+
+// unquote removes the quotes around the value, anything after the closing
+// quote (e.g. a comment) is ignored
+func unquote(value, quote string) string {
+	var res strings.Builder
+	escaped := false
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		if escaped {
+			switch c {
+			case 'n':
+				res.WriteByte('\n')
+			case 't':
+				res.WriteByte('\t')
+			case 'r':
+				res.WriteByte('\r')
+			default:
+				res.WriteByte(c)
+			}
+			escaped = false
+			continue
+		}
+		if quote == `"` && c == '\\' {
+			escaped = true
+			continue
+		}
+		if value[i:i+1] == quote {
+			break
+		}
+		res.WriteByte(c)
+	}
+	return res.String()
+}
+
+// writeVariables writes the variables in the given format, sorted by name
+func writeVariables(w stdio.Writer, variables scalingo.Variables, format ExportFormat) error {
+	sorted := append(scalingo.Variables{}, variables...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	switch format {
+	case ExportFormatDotenv:
+		for _, v := range sorted {
+			fmt.Fprintf(w, "%s=%s\n", v.Name, dotenvQuote(v.Value))
+		}
+	case ExportFormatShell:
+		for _, v := range sorted {
+			fmt.Fprintf(w, "export %s=%s\n", v.Name, shellQuote(v.Value))
+		}
+	case ExportFormatJSON:
+		values := map[string]string{}
+		for _, v := range sorted {
+			values[v.Name] = v.Value
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		// Values are often URLs, '&' must not be escaped
+		encoder.SetEscapeHTML(false)
+		err := encoder.Encode(values)
+		if err != nil {
+			return errgo.Notef(err, "fail to encode the variables to JSON")
+		}
+	default:
+		return format.validate()
+	}
+	return nil
+}
+
+func dotenvQuote(value string) string {
+	if unquotedValueFormat.MatchString(value) {
+		return value
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func shellQuote(value string) string {
+	return `'` + strings.ReplaceAll(value, `'`, `'\''`) + `'`
+}
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -20,19 +21,43 @@ var (
 	errInvalidNameFormat = fmt.Errorf("name can only be composed with alphanumerical characters, hyphens and underscores")
 )
 
-func Add(ctx context.Context, app string, params []string) error {
+type AddOpts struct {
+	// File is the path of a dotenv file whose variables are set. "-" reads the
+	// file from stdin.
+	File string
+}
+
+// Add sets the variables given as VAR=VAL parameters and the ones read from
+// opts.File in a single request. Parameters take precedence over the
+// variables of the file.
+func Add(ctx context.Context, app string, params []string, opts AddOpts) error {
 	var variables scalingo.Variables
+	if opts.File != "" {
+		fileVariables, err := readDotenvFile(opts.File)
+		if err != nil {
+			return errgo.Notef(err, "fail to read the variables from %s", opts.File)
+		}
+		variables = fileVariables
+	}
+
 	for _, param := range params {
 		if err := isEnvEditValid(param); err != nil {
 			return errgo.Newf("'%s' is invalid: %s", param, err)
 		}
 
 		name, value := parseVariable(param)
+		if v, ok := variables.Contains(name); ok {
+			v.Value = value
+			continue
+		}
 		variables = append(variables, &scalingo.Variable{
 			Name:  name,
 			Value: value,
 		})
 	}
+	if len(variables) == 0 {
+		return errgo.New("no variable to set")
+	}
 
 	c, err := config.ScalingoClient(ctx)
 	if err != nil {
@@ -43,11 +68,12 @@ func Add(ctx context.Context, app string, params []string) error {
 		return errgo.Mask(err, errgo.Any)
 	}
 
+	w := output.Writer(ctx)
 	for _, variable := range variables {
-		fmt.Printf("%s has been set to '%s'.\n", variable.Name, variable.Value)
+		fmt.Fprintf(w, "%s has been set to '%s'.\n", variable.Name, variable.Value)
 	}
-	fmt.Println("\nRestart your containers to apply these environment changes on your application:")
-	fmt.Printf("scalingo --app %s restart\n", app)
+	fmt.Fprintln(w, "\nRestart your containers to apply these environment changes on your application:")
+	fmt.Fprintf(w, "scalingo --app %s restart\n", app)
 
 	return nil
 }
@@ -73,15 +99,16 @@ func Delete(ctx context.Context, app string, varNames []string) error {
 		varsToUnset = append(varsToUnset, v)
 	}
 
+	w := output.Writer(ctx)
 	for _, v := range varsToUnset {
 		err := c.VariableUnset(ctx, app, v.ID)
 		if err != nil {
 			return errgo.Mask(err, errgo.Any)
 		}
-		fmt.Printf("%s has been unset.\n", v.Name)
+		fmt.Fprintf(w, "%s has been unset.\n", v.Name)
 	}
-	fmt.Println("\nRestart your containers to apply these environment changes on your application:")
-	fmt.Printf("scalingo --app %s restart\n", app)
+	fmt.Fprintln(w, "\nRestart your containers to apply these environment changes on your application:")
+	fmt.Fprintf(w, "scalingo --app %s restart\n", app)
 	return nil
 }
 
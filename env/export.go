@@ -0,0 +1,54 @@
+package env
+
+import (
+	"context"
+	"os"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+)
+
+type ExportOpts struct {
+	Format ExportFormat
+	// File is the path of the file the variables are written to. They are
+	// written on stdout if empty or "-".
+	File string
+}
+
+// Export writes the environment variables of an app in the dotenv, JSON or
+// shell format.
+func Export(ctx context.Context, app string, opts ExportOpts) error {
+	err := opts.Format.validate()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+	vars, err := c.VariablesList(ctx, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the environment variables")
+	}
+
+	if opts.File == "" || opts.File == "-" {
+		return writeVariables(os.Stdout, vars, opts.Format)
+	}
+
+	// The file contains secrets, it must only be readable by its owner
+	f, err := os.OpenFile(opts.File, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errgo.Notef(err, "fail to open file")
+	}
+	defer f.Close()
+
+	err = writeVariables(f, vars, opts.Format)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("%d environment variables of %s have been written to %s\n", len(vars), app, opts.File)
+	return nil
+}
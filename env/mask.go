@@ -0,0 +1,88 @@
+package env
+
+import (
+	"net/url"
+	"strings"
+)
+
+const maskedValue = "********"
+
+var (
+	// secretNameSuffixes are the suffixes of the names of the variables whose
+	// value is a secret
+	secretNameSuffixes = []string{"_TOKEN", "_SECRET", "_KEY", "_PASSWORD"}
+)
+
+// Reveal controls which secret values are displayed in clear text. Secret
+// values are masked unless All is true or their name is part of Keys.
+type Reveal struct {
+	All  bool
+	Keys []string
+}
+
+// Value returns the value to display for the variable name: the value itself
+// if it is not a secret or if it must be revealed, the masked value otherwise.
+func (r Reveal) Value(name, value string) string {
+	if r.All || !IsSecret(name, value) {
+		return value
+	}
+	for _, key := range r.Keys {
+		if key == name {
+			return value
+		}
+	}
+	return MaskValue(value)
+}
+
+// IsSecret returns true if the variable looks like a credential: its name ends
+// with _TOKEN, _SECRET, _KEY or _PASSWORD, or its value is a URL containing a
+// password (e.g. the SCALINGO_*_URL variables of the addons).
+func IsSecret(name, value string) bool {
+	upperName := strings.ToUpper(name)
+	for _, suffix := range secretNameSuffixes {
+		if strings.HasSuffix(upperName, suffix) {
+			return true
+		}
+	}
+	_, ok := urlPassword(value)
+	return ok
+}
+
+// MaskValue masks a secret value. Only the password of a URL is masked so
+// that the host and the user can still be read.
+func MaskValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	if _, ok := urlPassword(value); !ok {
+		return maskedValue
+	}
+	return MaskURL(value)
+}
+
+// MaskURL masks the password of a URL, the value is returned unchanged if it
+// is not a URL containing a password.
+func MaskURL(value string) string {
+	u, ok := urlPassword(value)
+	if !ok {
+		return value
+	}
+	userinfo := url.User(u.User.Username()).String() + ":" + maskedValue + "@"
+	u.User = nil
+	return strings.Replace(u.String(), "://", "://"+userinfo, 1)
+}
+
+// urlPassword returns the parsed URL if value is a URL containing a password
+func urlPassword(value string) (*url.URL, bool) {
+	if !strings.Contains(value, "://") {
+		return nil, false
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return nil, false
+	}
+	if _, ok := u.User.Password(); !ok {
+		return nil, false
+	}
+	return u, true
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 
+	"gopkg.in/errgo.v1"
+
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -13,6 +16,10 @@ type DisplayTimelineOpts struct {
 }
 
 func DisplayTimeline(events scalingo.Events, pagination scalingo.PaginationMeta, opts DisplayTimelineOpts) error {
+	if rendered, err := output.Data(events); rendered {
+		return errgo.Mask(err)
+	}
+
 	longestEventName := 0
 	longestAppName := 0
 	for _, event := range events {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/cli/utils"
 	scalingo "github.com/Scalingo/go-scalingo/v6"
 	"github.com/Scalingo/go-scalingo/v6/http"
@@ -31,6 +32,10 @@ func Show(ctx context.Context, app string) error {
 		return errgo.Notef(err, "fail to get integration link for this app")
 	}
 
+	if rendered, err := output.Data(repoLink); rendered {
+		return errgo.Mask(err)
+	}
+
 	fmt.Printf("%s: %s (%s)\n",
 		color.New(color.FgYellow).Sprint("Application"),
 		app, repoLink.AppID,
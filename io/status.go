@@ -2,6 +2,7 @@ package io
 
 import (
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -15,28 +16,54 @@ func Errorf(format string, args ...interface{}) {
 }
 
 func Warning(args ...interface{}) {
-	fmt.Print("  /!\\  ")
-	fmt.Println(args...)
+	Fwarning(os.Stdout, args...)
 }
 
 func Warningf(format string, args ...interface{}) {
-	fmt.Printf("  /!\\  "+format, args...)
+	Fwarningf(os.Stdout, format, args...)
 }
 
 func Status(args ...interface{}) {
-	fmt.Print("-----> ")
-	fmt.Println(args...)
+	Fstatus(os.Stdout, args...)
 }
 
 func Statusf(format string, args ...interface{}) {
-	fmt.Printf("-----> "+format, args...)
+	Fstatusf(os.Stdout, format, args...)
 }
 
 func Info(args ...interface{}) {
-	fmt.Print("       ")
-	fmt.Println(args...)
+	Finfo(os.Stdout, args...)
 }
 
 func Infof(format string, args ...interface{}) {
-	fmt.Printf("       "+format, args...)
+	Finfof(os.Stdout, format, args...)
+}
+
+// The F* variants write to w instead of the standard output
+
+func Fwarning(w io.Writer, args ...interface{}) {
+	fmt.Fprint(w, "  /!\\  ")
+	fmt.Fprintln(w, args...)
+}
+
+func Fwarningf(w io.Writer, format string, args ...interface{}) {
+	fmt.Fprintf(w, "  /!\\  "+format, args...)
+}
+
+func Fstatus(w io.Writer, args ...interface{}) {
+	fmt.Fprint(w, "-----> ")
+	fmt.Fprintln(w, args...)
+}
+
+func Fstatusf(w io.Writer, format string, args ...interface{}) {
+	fmt.Fprintf(w, "-----> "+format, args...)
+}
+
+func Finfo(w io.Writer, args ...interface{}) {
+	fmt.Fprint(w, "       ")
+	fmt.Fprintln(w, args...)
+}
+
+func Finfof(w io.Writer, format string, args ...interface{}) {
+	fmt.Fprintf(w, "       "+format, args...)
 }
@@ -2,12 +2,11 @@ package keys
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context) error {
@@ -20,7 +19,7 @@ func List(ctx context.Context) error {
 		return errgo.Notef(err, "fail to list SSH keys")
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(keys)
 	t.SetColWidth(60)
 	t.SetHeader([]string{"Name", "Content"})
 
@@ -28,6 +27,5 @@ func List(ctx context.Context) error {
 		t.Append([]string{k.Name, k.Content[0:20] + "..." + k.Content[len(k.Content)-30:]})
 	}
 
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -2,19 +2,18 @@ package logdrains
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
 type printableDrains struct {
-	DrainURLs []scalingo.LogDrain
-	AppName   string
+	DrainURLs []scalingo.LogDrain `json:"drains"`
+	AppName   string              `json:"name"`
 }
 
 type ListAddonOpts struct {
@@ -68,12 +67,11 @@ func List(ctx context.Context, app string, opts ListAddonOpts) error {
 		}
 	}
 
-	drawDrainsTable(appToPrint)
-	return nil
+	return drawDrainsTable(appToPrint)
 }
 
-func drawDrainsTable(drains []printableDrains) {
-	t := tablewriter.NewWriter(os.Stdout)
+func drawDrainsTable(drains []printableDrains) error {
+	t := output.NewTable(drains)
 	t.SetHeader([]string{"Name", "URL"})
 	t.SetAutoMergeCells(true)
 
@@ -90,5 +88,5 @@ func drawDrainsTable(drains []printableDrains) {
 			})
 		}
 	}
-	t.Render()
+	return t.Render()
 }
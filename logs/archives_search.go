@@ -0,0 +1,97 @@
+package logs
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+type SearchArchivesOpts struct {
+	Dir       string
+	Pattern   *regexp.Regexp
+	TimeRange TimeRange
+	Filter    string
+	Display   DisplayOpts
+}
+
+// SearchArchives displays the lines of the archives synced in opts.Dir which
+// match the pattern, in timestamp order. Only the archives overlapping the
+// time range, according to the index, and containing all the trigrams of the
+// literals of the pattern are read.
+func SearchArchives(ctx context.Context, opts SearchArchivesOpts) error {
+	index, err := ReadArchivesIndex(opts.Dir)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	w := &timeRangeWriter{
+		timeRange: opts.TimeRange,
+		filter:    opts.Filter,
+		pattern:   opts.Pattern,
+		display:   opts.Display,
+	}
+	var required trigrams
+	if opts.Pattern != nil {
+		required = patternTrigrams(opts.Pattern)
+	}
+	for _, a := range index.Archives {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !a.From.IsZero() && !a.To.IsZero() && !opts.TimeRange.overlaps(a.From, a.To) {
+			continue
+		}
+		path := filepath.Join(opts.Dir, a.File)
+		ok, err := mayMatch(path, required)
+		if err != nil {
+			return errgo.Notef(err, "fail to search in %s", a.File)
+		}
+		if !ok {
+			debug.Printf("[logs] Skip %s, it does not contain the pattern\n", a.File)
+			continue
+		}
+		messages, err := searchArchive(path, w)
+		if err != nil {
+			return errgo.Notef(err, "fail to search in %s", a.File)
+		}
+		w.writeSorted(messages)
+	}
+
+	if w.linesWritten == 0 {
+		io.Info("No log line matches the search")
+	}
+	return nil
+}
+
+// searchArchive returns the messages of an archive file which are displayed
+// by w
+func searchArchive(path string, w *timeRangeWriter) ([]logMessage, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to open the archive")
+	}
+	defer fd.Close()
+	gz, err := gzip.NewReader(fd)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to decompress the archive")
+	}
+	defer gz.Close()
+
+	var messages []logMessage
+	err = eachMessage(gz, func(m logMessage) {
+		if w.matches(m) {
+			messages = append(messages, m)
+		}
+	})
+	if err != nil {
+		return nil, errgo.Mask(err)
+	}
+	return messages, nil
+}
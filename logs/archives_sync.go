@@ -0,0 +1,361 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	stdio "io"
+
+	"github.com/cheggaaa/pb/v3"
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+const (
+	// ArchivesIndexFileName is the name of the index of the archives synced in
+	// a directory
+	ArchivesIndexFileName = "index.json"
+	// partialSuffix is the suffix of the archives being downloaded
+	partialSuffix = ".part"
+
+	archiveFileTimeLayout = "20060102T150405Z"
+)
+
+var unsafeFileNameRe = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// ArchivesIndex is the index of the logs archives synced in a directory. It
+// stores the time bounds of each archive, the content of each archive is
+// indexed in a trigrams file next to it.
+type ArchivesIndex struct {
+	App   string `json:"app"`
+	Addon string `json:"addon,omitempty"`
+	// Complete is true once all the archives have been synced, the next syncs
+	// stop at the first already synced page
+	Complete bool             `json:"complete"`
+	Archives []IndexedArchive `json:"archives"`
+}
+
+type IndexedArchive struct {
+	File string    `json:"file"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Size int64     `json:"size"`
+}
+
+type SyncArchivesOpts struct {
+	Dir   string
+	App   string
+	Addon string
+	// Concurrency is the number of archives downloaded in parallel
+	Concurrency int
+}
+
+// ReadArchivesIndex reads the index of the archives synced in dir
+func ReadArchivesIndex(dir string) (*ArchivesIndex, error) {
+	index, err := readArchivesIndex(dir)
+	if err != nil {
+		return nil, errgo.Mask(err)
+	}
+	if index == nil {
+		return nil, errgo.Newf("no logs archives in %s, sync them with 'scalingo logs-archives-sync --dir %s'", dir, dir)
+	}
+	return index, nil
+}
+
+// readArchivesIndex returns nil if there is no index in dir
+func readArchivesIndex(dir string) (*ArchivesIndex, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ArchivesIndexFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to read the archives index")
+	}
+	index := &ArchivesIndex{}
+	err = json.Unmarshal(content, index)
+	if err != nil {
+		return nil, errgo.Notef(err, "invalid archives index %s", filepath.Join(dir, ArchivesIndexFileName))
+	}
+	return index, nil
+}
+
+func (index *ArchivesIndex) write(dir string) error {
+	sort.Slice(index.Archives, func(i, j int) bool {
+		return index.Archives[i].From.Before(index.Archives[j].From)
+	})
+	content, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the archives index")
+	}
+
+	// The index is replaced atomically to stay valid if the sync is interrupted
+	indexPath := filepath.Join(dir, ArchivesIndexFileName)
+	err = os.WriteFile(indexPath+partialSuffix, content, 0644)
+	if err != nil {
+		return errgo.Notef(err, "fail to write the archives index")
+	}
+	return os.Rename(indexPath+partialSuffix, indexPath)
+}
+
+func (index *ArchivesIndex) has(file string) bool {
+	for _, a := range index.Archives {
+		if a.File == file {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncArchives downloads in opts.Dir the logs archives which have not been
+// downloaded yet. The downloads interrupted are resumed by the next sync.
+func SyncArchives(ctx context.Context, pager ArchivesPager, opts SyncArchivesOpts) error {
+	err := os.MkdirAll(opts.Dir, 0755)
+	if err != nil {
+		return errgo.Notef(err, "fail to create the directory %s", opts.Dir)
+	}
+
+	index, err := readArchivesIndex(opts.Dir)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	if index == nil {
+		index = &ArchivesIndex{App: opts.App, Addon: opts.Addon}
+	}
+	if index.App != opts.App || index.Addon != opts.Addon {
+		return errgo.Newf("%s contains the logs archives of another app or addon, use another directory", opts.Dir)
+	}
+
+	err = indexSyncedArchives(index, opts.Dir)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	io.Status("Listing the logs archives")
+	missing, err := missingArchives(ctx, pager, index)
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+	if len(missing) == 0 {
+		index.Complete = true
+		err = index.write(opts.Dir)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+		io.Statusf("The logs archives are up to date in %s\n", opts.Dir)
+		return nil
+	}
+
+	// Until all the missing archives are downloaded, the next syncs must list
+	// all the pages again, not stop at the first page already synced
+	if index.Complete {
+		index.Complete = false
+		err = index.write(opts.Dir)
+		if err != nil {
+			return errgo.Mask(err)
+		}
+	}
+
+	var total int64
+	for _, a := range missing {
+		total += a.Size
+	}
+	io.Statusf("Downloading %d logs archives in %s\n", len(missing), opts.Dir)
+	bar := pb.New64(total).
+		Set(pb.Bytes, true).
+		SetWriter(os.Stderr)
+	bar.Start()
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	archives := make(chan archiveToDownload)
+	errs := make(chan error, len(missing))
+	indexLock := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range archives {
+				err := downloadArchive(ctx, a, opts.Dir, bar)
+				if err != nil {
+					errs <- errgo.Notef(err, "fail to download the archive from %v to %v", a.From, a.To)
+					continue
+				}
+				err = writeArchiveTrigrams(filepath.Join(opts.Dir, a.File))
+				if err != nil {
+					errs <- errgo.Notef(err, "fail to index the archive from %v to %v", a.From, a.To)
+					continue
+				}
+				indexLock.Lock()
+				index.Archives = append(index.Archives, a.IndexedArchive)
+				err = index.write(opts.Dir)
+				indexLock.Unlock()
+				if err != nil {
+					errs <- errgo.Mask(err)
+				}
+			}
+		}()
+	}
+	for _, a := range missing {
+		archives <- a
+	}
+	close(archives)
+	wg.Wait()
+	bar.Finish()
+	close(errs)
+
+	failures := 0
+	for err := range errs {
+		failures++
+		io.Error(err.Error())
+	}
+	if failures > 0 {
+		return errgo.Newf("%d logs archives have not been downloaded, run the sync again to retry", failures)
+	}
+
+	index.Complete = true
+	err = index.write(opts.Dir)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("%d logs archives downloaded in %s\n", len(missing), opts.Dir)
+	return nil
+}
+
+// indexSyncedArchives lists the trigrams of the archives synced without them,
+// by a previous version
+func indexSyncedArchives(index *ArchivesIndex, dir string) error {
+	for _, a := range index.Archives {
+		path := filepath.Join(dir, a.File)
+		if _, err := os.Stat(path + trigramsSuffix); err == nil {
+			continue
+		}
+		err := writeArchiveTrigrams(path)
+		if err != nil {
+			return errgo.Notef(err, "fail to index the archive %s", a.File)
+		}
+	}
+	return nil
+}
+
+type archiveToDownload struct {
+	IndexedArchive
+	URL string
+}
+
+// missingArchives lists the archives which are not in the index. Once the
+// index is complete, the listing stops at the first page already synced.
+func missingArchives(ctx context.Context, pager ArchivesPager, index *ArchivesIndex) ([]archiveToDownload, error) {
+	var missing []archiveToDownload
+	listed := map[string]bool{}
+	for {
+		res, err := pager(ctx)
+		if err != nil {
+			return nil, errgo.Mask(err, errgo.Any)
+		}
+		if res == nil || len(res.Archives) == 0 {
+			break
+		}
+
+		synced := true
+		for _, item := range res.Archives {
+			a := archiveToDownload{URL: item.URL}
+			a.Size = item.Size
+			a.From, _ = parseArchiveTime(item.From)
+			a.To, _ = parseArchiveTime(item.To)
+			a.File = archiveFileName(a.From, a.To, item.URL)
+			if index.has(a.File) || listed[a.File] {
+				continue
+			}
+			listed[a.File] = true
+			synced = false
+			missing = append(missing, a)
+		}
+		if synced && index.Complete {
+			break
+		}
+	}
+	return missing, nil
+}
+
+// archiveFileName returns the name of the file of an archive, after its time
+// bounds or after its URL if they are unknown
+func archiveFileName(from, to time.Time, archiveURL string) string {
+	if !from.IsZero() && !to.IsZero() {
+		return fmt.Sprintf("%s-%s.log.gz", from.UTC().Format(archiveFileTimeLayout), to.UTC().Format(archiveFileTimeLayout))
+	}
+	name := archiveURL
+	if u, err := url.Parse(archiveURL); err == nil {
+		name = path.Base(u.Path)
+	}
+	return unsafeFileNameRe.ReplaceAllString(name, "_")
+}
+
+// downloadArchive downloads an archive in a partial file renamed once the
+// download is complete. A partial file left by an interrupted sync is resumed.
+func downloadArchive(ctx context.Context, a archiveToDownload, dir string, bar *pb.ProgressBar) error {
+	filePath := filepath.Join(dir, a.File)
+	partialPath := filePath + partialSuffix
+
+	var offset int64
+	if info, err := os.Stat(partialPath); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+	if err != nil {
+		return errgo.Notef(err, "invalid archive URL")
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errgo.Notef(err, "fail to start the download")
+	}
+	defer res.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch res.StatusCode {
+	case http.StatusPartialContent:
+		debug.Printf("[logs] Resume the download of %v at %v bytes\n", a.File, offset)
+		flags |= os.O_APPEND
+		bar.Add64(offset)
+	case http.StatusOK:
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is already complete
+		bar.Add64(offset)
+		return os.Rename(partialPath, filePath)
+	default:
+		return errgo.Newf("fail to download the archive: %s", res.Status)
+	}
+
+	fd, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return errgo.Notef(err, "fail to open %s", partialPath)
+	}
+	_, err = stdio.Copy(fd, bar.NewProxyReader(res.Body))
+	closeErr := fd.Close()
+	if err != nil {
+		return errgo.Notef(err, "fail to download the archive")
+	}
+	if closeErr != nil {
+		return errgo.Notef(closeErr, "fail to write %s", partialPath)
+	}
+	return os.Rename(partialPath, filePath)
+}
@@ -0,0 +1,191 @@
+package logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func gzipLines(t *testing.T, lines ...string) []byte {
+	buffer := &bytes.Buffer{}
+	gz := gzip.NewWriter(buffer)
+	_, err := gz.Write([]byte(strings.Join(lines, "\n") + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buffer.Bytes()
+}
+
+func TestSyncArchives(t *testing.T) {
+	archives := map[string][]byte{
+		"/first.gz": gzipLines(t,
+			"2026-10-10 13:10:00 +0000 UTC [web-1] Started GET /",
+			"2026-10-10 13:20:00 +0000 UTC [worker-1] Job timeout",
+		),
+		"/second.gz": gzipLines(t,
+			"2026-10-10 14:10:00 +0000 UTC [web-1] Request timeout",
+			"    at handler (server.js:42)",
+			"2026-10-10 14:05:00 +0000 UTC [web-2] Started GET /health",
+		),
+	}
+	var downloads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := archives[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&downloads, 1)
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	newPager := func() ArchivesPager {
+		pages := []*scalingo.LogsArchivesResponse{
+			{HasMore: true, NextCursor: "next", Archives: []scalingo.LogsArchiveItem{
+				{URL: server.URL + "/second.gz", From: "2026-10-10T14:00:00Z", To: "2026-10-10T15:00:00Z", Size: int64(len(archives["/second.gz"]))},
+			}},
+			{Archives: []scalingo.LogsArchiveItem{
+				{URL: server.URL + "/first.gz", From: "2026-10-10T13:00:00Z", To: "2026-10-10T14:00:00Z", Size: int64(len(archives["/first.gz"]))},
+			}},
+		}
+		return func(ctx context.Context) (*scalingo.LogsArchivesResponse, error) {
+			if len(pages) == 0 {
+				return nil, nil
+			}
+			page := pages[0]
+			pages = pages[1:]
+			return page, nil
+		}
+	}
+
+	dir := t.TempDir()
+	opts := SyncArchivesOpts{Dir: dir, App: "my-app", Concurrency: 2}
+
+	// An interrupted download is resumed
+	firstFile := "20261010T130000Z-20261010T140000Z.log.gz"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, firstFile+partialSuffix), archives["/first.gz"][:10], 0644))
+
+	err := SyncArchives(context.Background(), newPager(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&downloads))
+
+	index, err := ReadArchivesIndex(dir)
+	require.NoError(t, err)
+	assert.True(t, index.Complete)
+	require.Len(t, index.Archives, 2)
+	assert.Equal(t, firstFile, index.Archives[0].File)
+	assert.Equal(t, "20261010T140000Z-20261010T150000Z.log.gz", index.Archives[1].File)
+	for name, content := range map[string][]byte{firstFile: archives["/first.gz"], index.Archives[1].File: archives["/second.gz"]} {
+		downloaded, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, downloaded)
+		assert.FileExists(t, filepath.Join(dir, name+trigramsSuffix))
+	}
+
+	// The next sync does not download anything
+	err = SyncArchives(context.Background(), newPager(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&downloads))
+
+	// The directory can't be used for another app
+	err = SyncArchives(context.Background(), newPager(), SyncArchivesOpts{Dir: dir, App: "other-app"})
+	assert.ErrorContains(t, err, "another app")
+
+	// The archives are searched in the local directory
+	w := &timeRangeWriter{
+		timeRange: TimeRange{Until: time.Date(2026, 10, 10, 14, 30, 0, 0, time.UTC)},
+		pattern:   regexp.MustCompile("timeout"),
+	}
+	messages, err := searchArchive(filepath.Join(dir, index.Archives[1].File), w)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{
+		"2026-10-10 14:10:00 +0000 UTC [web-1] Request timeout",
+		"    at handler (server.js:42)",
+	}, messages[0].lines)
+}
+
+func TestSyncArchives_IncrementalSyncFailure(t *testing.T) {
+	archives := map[string][]byte{}
+	for _, name := range []string{"1", "2", "3", "4"} {
+		archives["/"+name+".gz"] = gzipLines(t, "2026-10-10 14:00:00 +0000 UTC [web-1] archive "+name)
+	}
+	var failing int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/3.gz" && atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(archives[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	item := func(name string, hour int) scalingo.LogsArchiveItem {
+		from := time.Date(2026, 10, 10, hour, 0, 0, 0, time.UTC)
+		return scalingo.LogsArchiveItem{
+			URL:  server.URL + "/" + name + ".gz",
+			From: from.Format(time.RFC3339),
+			To:   from.Add(time.Hour).Format(time.RFC3339),
+			Size: int64(len(archives["/"+name+".gz"])),
+		}
+	}
+	// newPager returns one archive per page, from the most recent one
+	newPager := func(items ...scalingo.LogsArchiveItem) ArchivesPager {
+		return func(ctx context.Context) (*scalingo.LogsArchivesResponse, error) {
+			if len(items) == 0 {
+				return nil, nil
+			}
+			page := &scalingo.LogsArchivesResponse{HasMore: len(items) > 1, Archives: items[:1]}
+			items = items[1:]
+			return page, nil
+		}
+	}
+
+	dir := t.TempDir()
+	opts := SyncArchivesOpts{Dir: dir, App: "my-app"}
+
+	err := SyncArchives(context.Background(), newPager(item("2", 13), item("1", 12)), opts)
+	require.NoError(t, err)
+
+	// The archive of the second page of the incremental sync fails
+	atomic.StoreInt32(&failing, 1)
+	err = SyncArchives(context.Background(), newPager(item("4", 15), item("3", 14), item("2", 13), item("1", 12)), opts)
+	require.Error(t, err)
+	index, err := ReadArchivesIndex(dir)
+	require.NoError(t, err)
+	assert.False(t, index.Complete)
+	assert.Len(t, index.Archives, 3)
+
+	// The retry lists the pages beyond the first one, which is already synced
+	atomic.StoreInt32(&failing, 0)
+	err = SyncArchives(context.Background(), newPager(item("4", 15), item("3", 14), item("2", 13), item("1", 12)), opts)
+	require.NoError(t, err)
+	index, err = ReadArchivesIndex(dir)
+	require.NoError(t, err)
+	assert.True(t, index.Complete)
+	assert.Len(t, index.Archives, 4)
+	_, err = os.Stat(filepath.Join(dir, "20261010T140000Z-20261010T150000Z.log.gz"))
+	assert.NoError(t, err)
+}
+
+func TestArchiveFileName(t *testing.T) {
+	from := time.Date(2026, 10, 10, 14, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	to := from.Add(time.Hour)
+
+	assert.Equal(t, "20261010T120000Z-20261010T130000Z.log.gz", archiveFileName(from, to, "https://example.com/a.gz?token=1"))
+	assert.Equal(t, "logs_1.gz", archiveFileName(time.Time{}, time.Time{}, "https://example.com/archives/logs%201.gz?token=1"))
+}
@@ -0,0 +1,162 @@
+package logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"os"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	errgo "gopkg.in/errgo.v1"
+)
+
+// trigramsSuffix is the suffix of the file listing the trigrams of a synced
+// archive
+const trigramsSuffix = ".trigrams"
+
+// trigram is the encoding of 3 consecutive bytes of a lowercased log line
+type trigram uint32
+
+// trigrams is the sorted list of the trigrams of an archive. The archives
+// which do not contain all the trigrams of the literals required by a search
+// pattern can't have a matching line and are not read.
+type trigrams []trigram
+
+func (t trigrams) contains(tri trigram) bool {
+	i := sort.Search(len(t), func(i int) bool { return t[i] >= tri })
+	return i < len(t) && t[i] == tri
+}
+
+// stringTrigrams adds the trigrams of the lowercased s to set
+func stringTrigrams(s string, set map[trigram]bool) {
+	b := []byte(strings.ToLower(s))
+	for i := 0; i+3 <= len(b); i++ {
+		set[trigram(b[i])<<16|trigram(b[i+1])<<8|trigram(b[i+2])] = true
+	}
+}
+
+func sortedTrigrams(set map[trigram]bool) trigrams {
+	t := make(trigrams, 0, len(set))
+	for tri := range set {
+		t = append(t, tri)
+	}
+	sort.Slice(t, func(i, j int) bool { return t[i] < t[j] })
+	return t
+}
+
+// writeArchiveTrigrams lists the trigrams of the lines of the archive file
+// in a file next to it
+func writeArchiveTrigrams(archivePath string) error {
+	fd, err := os.Open(archivePath)
+	if err != nil {
+		return errgo.Notef(err, "fail to open the archive")
+	}
+	defer fd.Close()
+	gz, err := gzip.NewReader(fd)
+	if err != nil {
+		return errgo.Notef(err, "fail to decompress the archive")
+	}
+	defer gz.Close()
+
+	set := map[trigram]bool{}
+	err = eachLine(gz, func(line string) {
+		stringTrigrams(line, set)
+	})
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	buffer := &bytes.Buffer{}
+	err = binary.Write(buffer, binary.LittleEndian, sortedTrigrams(set))
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the trigrams")
+	}
+	path := archivePath + trigramsSuffix
+	err = os.WriteFile(path+partialSuffix, buffer.Bytes(), 0644)
+	if err != nil {
+		return errgo.Notef(err, "fail to write the trigrams of the archive")
+	}
+	return os.Rename(path+partialSuffix, path)
+}
+
+// readArchiveTrigrams returns nil if the trigrams of the archive file have
+// not been listed, e.g. by a sync of a previous version
+func readArchiveTrigrams(archivePath string) (trigrams, error) {
+	content, err := os.ReadFile(archivePath + trigramsSuffix)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to read the trigrams of the archive")
+	}
+	if len(content)%4 != 0 {
+		return nil, errgo.Newf("invalid trigrams file %s", archivePath+trigramsSuffix)
+	}
+	t := make(trigrams, len(content)/4)
+	err = binary.Read(bytes.NewReader(content), binary.LittleEndian, t)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to decode the trigrams of the archive")
+	}
+	return t, nil
+}
+
+// patternTrigrams returns the trigrams that any line matching the pattern
+// contains. It is empty if the pattern has no literal of at least 3 bytes
+// which is always matched.
+func patternTrigrams(pattern *regexp.Regexp) trigrams {
+	re, err := syntax.Parse(pattern.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	set := map[trigram]bool{}
+	for _, literal := range requiredLiterals(re) {
+		stringTrigrams(literal, set)
+	}
+	return sortedTrigrams(set)
+}
+
+// requiredLiterals returns literals that any match of re contains. The
+// literals of the alternations and of the optional parts are ignored.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min >= 1 {
+			return requiredLiterals(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		var literals []string
+		for _, sub := range re.Sub {
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		return literals
+	}
+	return nil
+}
+
+// mayMatch returns false if the archive can't contain a line with all the
+// trigrams. Without trigrams file, the archive has to be read.
+func mayMatch(archivePath string, required trigrams) (bool, error) {
+	if len(required) == 0 {
+		return true, nil
+	}
+	t, err := readArchiveTrigrams(archivePath)
+	if err != nil {
+		return false, errgo.Mask(err)
+	}
+	if t == nil {
+		return true, nil
+	}
+	for _, tri := range required {
+		if !t.contains(tri) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,77 @@
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	tests := map[string]struct {
+		pattern          string
+		expectedLiterals []string
+	}{
+		"a word": {
+			pattern:          "timeout",
+			expectedLiterals: []string{"timeout"},
+		},
+		"literals around a class": {
+			pattern:          "user [0-9]+ not found",
+			expectedLiterals: []string{"user ", " not found"},
+		},
+		"a repeated group": {
+			pattern:          "(error: )+oops",
+			expectedLiterals: []string{"error: ", "oops"},
+		},
+		"an optional part": {
+			pattern:          "time(out)?",
+			expectedLiterals: []string{"time"},
+		},
+		"an alternation": {
+			pattern:          "timeout|refused",
+			expectedLiterals: nil,
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			re, err := syntax.Parse(test.pattern, syntax.Perl)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedLiterals, requiredLiterals(re))
+		})
+	}
+}
+
+func TestMayMatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "archive.log.gz")
+	require.NoError(t, os.WriteFile(path, gzipLines(t,
+		"2026-10-10 14:10:00 +0000 UTC [web-1] Request Timeout",
+		"2026-10-10 14:10:01 +0000 UTC [web-1] user 42 not found",
+	), 0644))
+
+	// Without trigrams file, the archive has to be read
+	ok, err := mayMatch(path, patternTrigrams(regexp.MustCompile("refused")))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, writeArchiveTrigrams(path))
+
+	for pattern, expected := range map[string]bool{
+		"(?i)timeout":           true,
+		"user [0-9]+ not found": true,
+		"refused":               false,
+		"user [0-9]+ deleted":   false,
+		"timeout|refused":       true,
+		"ab":                    true,
+	} {
+		ok, err := mayMatch(path, patternTrigrams(regexp.MustCompile(pattern)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, ok, pattern)
+	}
+}
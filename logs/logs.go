@@ -36,7 +36,7 @@ type WSEvent struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
-func Dump(ctx context.Context, logsURL string, n int, filter string) error {
+func Dump(ctx context.Context, logsURL string, n int, filter string, opts DisplayOpts) error {
 	c, err := config.ScalingoClient(ctx)
 	if err != nil {
 		return errgo.Notef(err, "fail to get Scalingo client")
@@ -76,7 +76,7 @@ func Dump(ctx context.Context, logsURL string, n int, filter string) error {
 	go func() {
 		defer wg.Done()
 		for bline := range buff {
-			colorizeLogs(string(bline))
+			displayLogs(string(bline), opts)
 		}
 	}()
 
@@ -116,7 +116,16 @@ func Dump(ctx context.Context, logsURL string, n int, filter string) error {
 	}
 }
 
-func Stream(ctx context.Context, logsRawURL string, filter string) error {
+func Stream(ctx context.Context, logsRawURL string, filter string, opts DisplayOpts) error {
+	return streamEvents(ctx, logsRawURL, filter, func(event WSEvent) {
+		displayLogs(strings.TrimSpace(event.Log), opts)
+	})
+}
+
+// streamEvents calls onLog with each log event of the websocket of the logs,
+// until the user interrupts it. The websocket is reconnected if the remote
+// server breaks the connection.
+func streamEvents(ctx context.Context, logsRawURL string, filter string, onLog func(WSEvent)) error {
 	var (
 		err   error
 		event WSEvent
@@ -179,7 +188,7 @@ func Stream(ctx context.Context, logsRawURL string, filter string) error {
 			switch event.Type {
 			case "ping":
 			case "log":
-				colorizeLogs(strings.TrimSpace(event.Log))
+				onLog(event)
 			}
 		}
 	}
@@ -187,19 +196,23 @@ func Stream(ctx context.Context, logsRawURL string, filter string) error {
 
 type colorFunc func(...interface{}) string
 
-func colorizeLogs(logs string) {
-	containerColors := []colorFunc{
-		color.New(color.FgBlue).SprintFunc(),
-		color.New(color.FgCyan).SprintFunc(),
-		color.New(color.FgGreen).SprintFunc(),
-		color.New(color.FgMagenta).SprintFunc(),
-		color.New(color.FgHiYellow).SprintFunc(),
-		color.New(color.FgHiBlue).SprintFunc(),
-		color.New(color.FgHiCyan).SprintFunc(),
-		color.New(color.FgHiGreen).SprintFunc(),
-		color.New(color.FgHiMagenta).SprintFunc(),
-	}
+var containerColors = []colorFunc{
+	color.New(color.FgBlue).SprintFunc(),
+	color.New(color.FgCyan).SprintFunc(),
+	color.New(color.FgGreen).SprintFunc(),
+	color.New(color.FgMagenta).SprintFunc(),
+	color.New(color.FgHiYellow).SprintFunc(),
+	color.New(color.FgHiBlue).SprintFunc(),
+	color.New(color.FgHiCyan).SprintFunc(),
+	color.New(color.FgHiGreen).SprintFunc(),
+	color.New(color.FgHiMagenta).SprintFunc(),
+}
 
+// displayLogs displays the log lines matching the conditions of opts. The
+// lines are colorized, unless the raw output is requested, and the requested
+// fields of the structured lines are pretty-printed.
+func displayLogs(logs string, opts DisplayOpts) {
+	prefix := opts.sourcePrefix()
 	lines := strings.Split(logs, "\n")
 
 	for _, line := range lines {
@@ -207,9 +220,25 @@ func colorizeLogs(logs string) {
 			continue
 		}
 
+		var fields map[string]interface{}
+		if len(opts.Where) > 0 || len(opts.JSONFields) > 0 {
+			fields = parseFields(logContent(line))
+		}
+		if len(opts.Where) > 0 && !matchAll(opts.Where, fields) {
+			continue
+		}
+		if opts.RouterStats != nil {
+			opts.RouterStats.Add(line)
+			continue
+		}
+		if opts.Raw {
+			fmt.Println(prefix + line)
+			continue
+		}
+
 		lineSplit := strings.Split(line, " ")
 		if len(lineSplit) < 5 {
-			fmt.Println(line)
+			fmt.Println(prefix + line)
 			continue
 		}
 		content := strings.Join(lineSplit[5:], " ")
@@ -224,7 +253,14 @@ func colorizeLogs(logs string) {
 			colorId += int(letter)
 		}
 
-		if container == "router" {
+		if fields != nil && len(opts.JSONFields) > 0 {
+			if formatted := formatFields(fields, opts.JSONFields); formatted != "" {
+				content = formatted
+			}
+			if container == "router" {
+				colorId += 6
+			}
+		} else if container == "router" {
 			colorId += 6
 			content = colorizeRouterLogs(content)
 		} else {
@@ -233,7 +269,8 @@ func colorizeLogs(logs string) {
 		colorId = colorId % len(containerColors)
 
 		fmt.Printf(
-			"%s [%s] %s\n",
+			"%s%s [%s] %s\n",
+			prefix,
 			color.New(color.FgYellow).Sprint(date),
 			containerColors[colorId](container),
 			content,
@@ -241,6 +278,16 @@ func colorizeLogs(logs string) {
 	}
 }
 
+// logContent returns the content of a log line, without the date and the
+// container
+func logContent(line string) string {
+	lineSplit := strings.SplitN(line, " ", 6)
+	if len(lineSplit) < 6 {
+		return ""
+	}
+	return lineSplit[5]
+}
+
 const (
 	varNameState int = iota
 	equalState
@@ -0,0 +1,405 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	stdio "io"
+
+	"github.com/fatih/color"
+	"github.com/gosuri/uilive"
+
+	"github.com/Scalingo/cli/output"
+)
+
+const (
+	// routerStatsTopCount is the number of paths, client IPs and slowest
+	// requests of the summary
+	routerStatsTopCount = 5
+	// routerStatsSampleSize is the maximum number of response times kept to
+	// compute the percentiles. Beyond it, the response times are a uniform
+	// random sample of all the requests, so the memory stays bounded with
+	// --follow.
+	routerStatsSampleSize = 10000
+	// routerStatsTrackedValues is the maximum number of paths and client IPs
+	// counted to find the top ones
+	routerStatsTrackedValues = 1000
+)
+
+type routerRequest struct {
+	Time     time.Time     `json:"time"`
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`
+	Status   int           `json:"status"`
+	Duration time.Duration `json:"duration"`
+	ClientIP string        `json:"client_ip"`
+}
+
+// RouterStats aggregates the router log lines to summarize the traffic of an
+// app. It is safe for concurrent use.
+type RouterStats struct {
+	lock sync.Mutex
+
+	requests int
+	first    time.Time
+	last     time.Time
+	// lastMinute is the minute of the most recent request and
+	// lastMinuteRequests the number of requests during this minute
+	lastMinute         time.Time
+	lastMinuteRequests int
+	statuses           map[string]int
+	// durations is a sample of at most routerStatsSampleSize response times
+	durations []time.Duration
+	random    *rand.Rand
+	paths     *topCounter
+	clients   *topCounter
+	slowest   []routerRequest
+
+	stop chan struct{}
+	done chan struct{}
+	live *uilive.Writer
+}
+
+type RouterStatsSummary struct {
+	Requests           int               `json:"requests"`
+	From               time.Time         `json:"from"`
+	To                 time.Time         `json:"to"`
+	RequestsPerMinute  float64           `json:"requests_per_minute"`
+	RequestsLastMinute int               `json:"requests_last_minute"`
+	Statuses           map[string]int    `json:"statuses"`
+	P50                time.Duration     `json:"p50"`
+	P95                time.Duration     `json:"p95"`
+	P99                time.Duration     `json:"p99"`
+	TopPaths           []RouterStatsItem `json:"top_paths"`
+	TopClientIPs       []RouterStatsItem `json:"top_client_ips"`
+	Slowest            []routerRequest   `json:"slowest"`
+}
+
+type RouterStatsItem struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+func NewRouterStats() *RouterStats {
+	return &RouterStats{
+		statuses: map[string]int{},
+		random:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		paths:    newTopCounter(routerStatsTrackedValues),
+		clients:  newTopCounter(routerStatsTrackedValues),
+	}
+}
+
+// Add aggregates a log line, the lines which are not router requests are
+// ignored
+func (s *RouterStats) Add(line string) {
+	request, ok := parseRouterRequest(line)
+	if !ok {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests++
+	if s.first.IsZero() || request.Time.Before(s.first) {
+		s.first = request.Time
+	}
+	if request.Time.After(s.last) {
+		s.last = request.Time
+	}
+	minute := request.Time.Truncate(time.Minute)
+	if minute.After(s.lastMinute) {
+		s.lastMinute = minute
+		s.lastMinuteRequests = 0
+	}
+	if minute.Equal(s.lastMinute) {
+		s.lastMinuteRequests++
+	}
+	s.statuses[statusClass(request.Status)]++
+	s.sampleDuration(request.Duration)
+	s.paths.add(request.Path)
+	if request.ClientIP != "" {
+		s.clients.add(request.ClientIP)
+	}
+
+	s.slowest = append(s.slowest, request)
+	sort.SliceStable(s.slowest, func(i, j int) bool {
+		return s.slowest[i].Duration > s.slowest[j].Duration
+	})
+	if len(s.slowest) > routerStatsTopCount {
+		s.slowest = s.slowest[:routerStatsTopCount]
+	}
+}
+
+// sampleDuration keeps a uniform random sample of the response times with the
+// reservoir sampling algorithm
+func (s *RouterStats) sampleDuration(d time.Duration) {
+	if len(s.durations) < routerStatsSampleSize {
+		s.durations = append(s.durations, d)
+		return
+	}
+	if i := s.random.Intn(s.requests); i < routerStatsSampleSize {
+		s.durations[i] = d
+	}
+}
+
+// Summary returns the statistics of the requests aggregated so far
+func (s *RouterStats) Summary() RouterStatsSummary {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	summary := RouterStatsSummary{
+		Requests:           s.requests,
+		From:               s.first,
+		To:                 s.last,
+		RequestsLastMinute: s.lastMinuteRequests,
+		Statuses:           map[string]int{},
+		TopPaths:           topItems(s.paths.counts, routerStatsTopCount),
+		TopClientIPs:       topItems(s.clients.counts, routerStatsTopCount),
+		Slowest:            append([]routerRequest{}, s.slowest...),
+	}
+	for class, count := range s.statuses {
+		summary.Statuses[class] = count
+	}
+	if s.requests > 0 {
+		minutes := math.Max(s.last.Sub(s.first).Minutes(), 1)
+		summary.RequestsPerMinute = float64(s.requests) / minutes
+	}
+
+	durations := append([]time.Duration{}, s.durations...)
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	summary.P50 = percentile(durations, 50)
+	summary.P95 = percentile(durations, 95)
+	summary.P99 = percentile(durations, 99)
+	return summary
+}
+
+// Live refreshes the summary on the standard output every interval until
+// Finish is called
+func (s *RouterStats) Live(interval time.Duration) {
+	if !output.IsHuman() {
+		return
+	}
+	s.live = uilive.New()
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.Summary().write(s.live)
+				s.live.Flush()
+			}
+		}
+	}()
+}
+
+// Finish stops the live refresh and displays the final summary
+func (s *RouterStats) Finish() error {
+	summary := s.Summary()
+	if !output.IsHuman() {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	}
+
+	if s.live == nil {
+		summary.write(os.Stdout)
+		return nil
+	}
+	close(s.stop)
+	<-s.done
+	summary.write(s.live)
+	s.live.Flush()
+	return nil
+}
+
+func (summary RouterStatsSummary) write(w stdio.Writer) {
+	bold := color.New(color.Bold).SprintFunc()
+	if summary.Requests == 0 {
+		fmt.Fprintln(w, "No router log line, ensure the router logs are enabled with 'scalingo router-logs --enable'")
+		return
+	}
+
+	fmt.Fprintf(w, "%s %d requests from %s to %s\n", bold("Router logs:"),
+		summary.Requests, summary.From.Format("2006-01-02 15:04:05"), summary.To.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "%s %.1f (last minute: %d)\n", bold("Requests per minute:"), summary.RequestsPerMinute, summary.RequestsLastMinute)
+	fmt.Fprintf(w, "%s p50 %v, p95 %v, p99 %v\n", bold("Response time:"),
+		roundDuration(summary.P50), roundDuration(summary.P95), roundDuration(summary.P99))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\n%s\n", bold("Status codes"))
+	classes := make([]string, 0, len(summary.Statuses))
+	for class := range summary.Statuses {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	for _, class := range classes {
+		count := summary.Statuses[class]
+		fmt.Fprintf(tw, "  %s\t%d\t%.1f%%\n", colorizeStatus(class), count, 100*float64(count)/float64(summary.Requests))
+	}
+
+	fmt.Fprintf(tw, "\n%s\n", bold("Top paths"))
+	for _, item := range summary.TopPaths {
+		fmt.Fprintf(tw, "  %d\t%s\n", item.Count, item.Value)
+	}
+	fmt.Fprintf(tw, "\n%s\n", bold("Top client IPs"))
+	for _, item := range summary.TopClientIPs {
+		fmt.Fprintf(tw, "  %d\t%s\n", item.Count, item.Value)
+	}
+	fmt.Fprintf(tw, "\n%s\n", bold("Slowest requests"))
+	for _, request := range summary.Slowest {
+		fmt.Fprintf(tw, "  %v\t%s\t%s %s\t%s\n", roundDuration(request.Duration),
+			colorizeStatus(strconv.Itoa(request.Status)), request.Method, request.Path, request.Time.Format("15:04:05"))
+	}
+	tw.Flush()
+}
+
+// parseRouterRequest parses a router log line, e.g.
+// '... [router] method=GET path="/" host=my-app.osc-fr1.scalingo.io
+// request_id=… from="1.2.3.4" protocol=https status=200 duration=0.012s …'
+func parseRouterRequest(line string) (routerRequest, bool) {
+	t, ok := lineTimestamp(line)
+	if !ok || !matchContainerFilter(line, "router") {
+		return routerRequest{}, false
+	}
+	fields := parseFields(logContent(line))
+	if fields == nil {
+		return routerRequest{}, false
+	}
+
+	request := routerRequest{Time: t}
+	status, ok := lookupField(fields, "status")
+	if !ok {
+		return routerRequest{}, false
+	}
+	request.Status, _ = strconv.Atoi(fieldString(status))
+
+	if duration, ok := lookupField(fields, "duration"); ok {
+		request.Duration = parseRouterDuration(fieldString(duration))
+	}
+	if method, ok := lookupField(fields, "method"); ok {
+		request.Method = fieldString(method)
+	}
+	if path, ok := lookupField(fields, "path"); ok {
+		request.Path = strings.SplitN(fieldString(path), "?", 2)[0]
+	}
+	if from, ok := lookupField(fields, "from"); ok {
+		request.ClientIP = fieldString(from)
+		if host, _, err := net.SplitHostPort(request.ClientIP); err == nil {
+			request.ClientIP = host
+		}
+	}
+	return request, true
+}
+
+// parseRouterDuration parses '0.012s' or '12ms', a number without unit is in
+// seconds
+func parseRouterDuration(value string) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil {
+		return d
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	return 0
+}
+
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "other"
+	}
+	return fmt.Sprintf("%dxx", status/100)
+}
+
+func colorizeStatus(status string) string {
+	switch {
+	case strings.HasPrefix(status, "5"):
+		return color.New(color.FgRed).Sprint(status)
+	case strings.HasPrefix(status, "4"):
+		return color.New(color.FgYellow).Sprint(status)
+	case strings.HasPrefix(status, "2"):
+		return color.New(color.FgGreen).Sprint(status)
+	}
+	return status
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	if d > time.Second {
+		return d.Round(10 * time.Millisecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
+
+// topCounter counts the occurrences of at most capacity values. When it is
+// full, a new value replaces the least counted one and inherits its count
+// (space-saving algorithm): the counts of the new values are overestimated
+// but the most frequent values are kept, whatever the number of distinct
+// values.
+type topCounter struct {
+	capacity int
+	counts   map[string]int
+}
+
+func newTopCounter(capacity int) *topCounter {
+	return &topCounter{capacity: capacity, counts: map[string]int{}}
+}
+
+func (c *topCounter) add(value string) {
+	if _, ok := c.counts[value]; ok || len(c.counts) < c.capacity {
+		c.counts[value]++
+		return
+	}
+	minValue, minCount := "", 0
+	for v, count := range c.counts {
+		if minValue == "" || count < minCount {
+			minValue, minCount = v, count
+		}
+	}
+	delete(c.counts, minValue)
+	c.counts[value] = minCount + 1
+}
+
+func topItems(counts map[string]int, n int) []RouterStatsItem {
+	items := make([]RouterStatsItem, 0, len(counts))
+	for value, count := range counts {
+		items = append(items, RouterStatsItem{Value: value, Count: count})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Count != items[j].Count {
+			return items[i].Count > items[j].Count
+		}
+		return items[i].Value < items[j].Value
+	})
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
@@ -0,0 +1,98 @@
+package logs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRouterRequest(t *testing.T) {
+	request, ok := parseRouterRequest(`2026-10-10 14:00:05.5 +0000 UTC [router] method=POST path="/api/orders?page=2" host=my-app.osc-fr1.scalingo.io request_id=abc from="203.0.113.7" protocol=https status=502 duration=1.25s bytes=120 user_agent="curl/8.0"`)
+	require.True(t, ok)
+	assert.Equal(t, routerRequest{
+		Time:     time.Date(2026, 10, 10, 14, 0, 5, 500000000, time.UTC),
+		Method:   "POST",
+		Path:     "/api/orders",
+		Status:   502,
+		Duration: 1250 * time.Millisecond,
+		ClientIP: "203.0.113.7",
+	}, request)
+
+	_, ok = parseRouterRequest("2026-10-10 14:00:05 +0000 UTC [web-1] method=GET status=200")
+	assert.False(t, ok)
+
+	_, ok = parseRouterRequest("2026-10-10 14:00:05 +0000 UTC [router] Router restarted")
+	assert.False(t, ok)
+}
+
+func TestRouterStats_Summary(t *testing.T) {
+	stats := NewRouterStats()
+	for i := 1; i <= 100; i++ {
+		status := 200
+		if i%10 == 0 {
+			status = 503
+		}
+		path := "/"
+		if i%2 == 0 {
+			path = "/api"
+		}
+		stats.Add(fmt.Sprintf(
+			"2026-10-10 14:%02d:00 +0000 UTC [router] method=GET path=%s from=10.0.0.%d status=%d duration=%dms",
+			i/10, path, i%3, status, i,
+		))
+	}
+	stats.Add("2026-10-10 14:05:00 +0000 UTC [web-1] Not a router line")
+
+	summary := stats.Summary()
+	assert.Equal(t, 100, summary.Requests)
+	assert.Equal(t, time.Date(2026, 10, 10, 14, 0, 0, 0, time.UTC), summary.From)
+	assert.Equal(t, time.Date(2026, 10, 10, 14, 10, 0, 0, time.UTC), summary.To)
+	assert.InDelta(t, 10, summary.RequestsPerMinute, 0.01)
+	assert.Equal(t, 1, summary.RequestsLastMinute)
+	assert.Equal(t, map[string]int{"2xx": 90, "5xx": 10}, summary.Statuses)
+	assert.Equal(t, 50*time.Millisecond, summary.P50)
+	assert.Equal(t, 95*time.Millisecond, summary.P95)
+	assert.Equal(t, 99*time.Millisecond, summary.P99)
+	assert.Equal(t, []RouterStatsItem{{Value: "/", Count: 50}, {Value: "/api", Count: 50}}, summary.TopPaths)
+	assert.Equal(t, RouterStatsItem{Value: "10.0.0.1", Count: 34}, summary.TopClientIPs[0])
+	require.Len(t, summary.Slowest, routerStatsTopCount)
+	assert.Equal(t, 100*time.Millisecond, summary.Slowest[0].Duration)
+	assert.Equal(t, 503, summary.Slowest[0].Status)
+	assert.Equal(t, 96*time.Millisecond, summary.Slowest[4].Duration)
+}
+
+func TestRouterStats_BoundedMemory(t *testing.T) {
+	stats := NewRouterStats()
+	for i := 0; i < 3*routerStatsSampleSize; i++ {
+		stats.Add(fmt.Sprintf(
+			"2026-10-10 14:%02d:%02d +0000 UTC [router] method=GET path=/ status=200 duration=%dms",
+			i/6000, i/100%60, i%1000,
+		))
+	}
+
+	assert.Len(t, stats.durations, routerStatsSampleSize)
+	assert.Len(t, stats.paths.counts, 1)
+	summary := stats.Summary()
+	assert.Equal(t, 3*routerStatsSampleSize, summary.Requests)
+	assert.Equal(t, 6000, summary.RequestsLastMinute)
+	assert.InDelta(t, 500*time.Millisecond, summary.P50, float64(50*time.Millisecond))
+}
+
+func TestTopCounter(t *testing.T) {
+	counter := newTopCounter(10)
+	for i := 0; i < 1000; i++ {
+		counter.add("/")
+		counter.add(fmt.Sprintf("/orders/%d", i))
+		if i%2 == 0 {
+			counter.add("/api")
+		}
+	}
+
+	assert.Len(t, counter.counts, 10)
+	top := topItems(counter.counts, 2)
+	assert.Equal(t, RouterStatsItem{Value: "/", Count: 1000}, top[0])
+	assert.Equal(t, "/api", top[1].Value)
+}
@@ -0,0 +1,234 @@
+package logs
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+)
+
+const (
+	// sourcesMergeDelay is how long the streamed lines are kept to be sorted
+	// with the lines of the other sources
+	sourcesMergeDelay = time.Second
+	// sourcesFlushInterval is the interval between two displays of the
+	// streamed lines
+	sourcesFlushInterval = 250 * time.Millisecond
+)
+
+// Source is one of the logs merged by Sources: the logs of an app or of an
+// addon
+type Source struct {
+	// Name is the tag displayed before each line, e.g. 'api' or
+	// 'api/postgresql'
+	Name    string
+	LogsURL string
+	// Filter is the containers filter, for the apps only
+	Filter string
+}
+
+type SourcesOpts struct {
+	Count   int
+	Follow  bool
+	Display DisplayOpts
+}
+
+// sourcePrefix returns the colored tag of the source, if any
+func (opts DisplayOpts) sourcePrefix() string {
+	if opts.source == "" {
+		return ""
+	}
+	if opts.Raw {
+		return "[" + opts.source + "] "
+	}
+	colorID := 0
+	for _, letter := range []byte(opts.source) {
+		colorID += int(letter)
+	}
+	return color.New(color.Bold).Sprint(containerColors[colorID%len(containerColors)]("["+opts.source+"]")) + " "
+}
+
+type sourceLine struct {
+	t      time.Time
+	line   string
+	source int
+}
+
+// Sources displays the logs of several sources, each line is prefixed by the
+// name of its source. The last opts.Count lines of each source are displayed
+// in timestamp order then, with opts.Follow, the streams of the sources are
+// merged in timestamp order.
+func Sources(ctx context.Context, sources []Source, opts SourcesOpts) error {
+	displayOpts := make([]DisplayOpts, len(sources))
+	for i, source := range sources {
+		displayOpts[i] = opts.Display
+		displayOpts[i].source = source.Name
+	}
+
+	err := dumpSources(ctx, sources, opts.Count, displayOpts)
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+	if !opts.Follow {
+		return nil
+	}
+	return streamSources(ctx, sources, displayOpts)
+}
+
+func dumpSources(ctx context.Context, sources []Source, count int, displayOpts []DisplayOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	sourcesLines := make([][]string, len(sources))
+	errs := make([]error, len(sources))
+	wg := &sync.WaitGroup{}
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source Source) {
+			defer wg.Done()
+			res, err := c.Logs(ctx, source.LogsURL, count, source.Filter)
+			if err != nil {
+				errs[i] = errgo.Notef(err, "fail to get the logs of %s", source.Name)
+				return
+			}
+			defer res.Body.Close()
+			if res.StatusCode == 404 || res.StatusCode == 204 {
+				return
+			}
+			errs[i] = eachLine(res.Body, func(line string) {
+				sourcesLines[i] = append(sourcesLines[i], line)
+			})
+		}(i, source)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	var lines []sourceLine
+	for i, sourceLines := range sourcesLines {
+		lines = append(lines, timedSourceLines(sourceLines, i)...)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].t.Before(lines[j].t)
+	})
+	for _, l := range lines {
+		displayLogs(l.line, displayOpts[l.source])
+	}
+	return nil
+}
+
+// timedSourceLines returns the lines of a source with their timestamp. The
+// lines without timestamp, like the lines of a multi-line message, get the
+// timestamp of the previous line to stay after it.
+func timedSourceLines(lines []string, source int) []sourceLine {
+	timed := make([]sourceLine, 0, len(lines))
+	var last time.Time
+	for _, line := range lines {
+		if t, ok := lineTimestamp(line); ok {
+			last = t
+		}
+		timed = append(timed, sourceLine{t: last, line: line, source: source})
+	}
+	return timed
+}
+
+// streamSources streams the logs of all the sources until the user interrupts
+// them. Each stream reconnects on its own. The lines are kept
+// sourcesMergeDelay to be displayed in timestamp order with the lines of the
+// other sources.
+func streamSources(ctx context.Context, sources []Source, displayOpts []DisplayOpts) error {
+	lines := make(chan sourceLine, 1000)
+
+	wg := &sync.WaitGroup{}
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source Source) {
+			defer wg.Done()
+			err := streamEvents(ctx, source.LogsURL, source.Filter, func(event WSEvent) {
+				log := strings.TrimSpace(event.Log)
+				t := event.Timestamp
+				if lineT, ok := lineTimestamp(log); ok {
+					t = lineT
+				}
+				lines <- sourceLine{t: t, line: log, source: i}
+			})
+			if err != nil {
+				io.Errorf("The logs stream of %s stopped: %v\n", source.Name, err)
+			}
+		}(i, source)
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	merger := &sourcesMerger{displayOpts: displayOpts}
+	ticker := time.NewTicker(sourcesFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				merger.flush(time.Time{})
+				return nil
+			}
+			merger.add(line, time.Now())
+		case now := <-ticker.C:
+			merger.flush(now.Add(-sourcesMergeDelay))
+		}
+	}
+}
+
+type receivedLine struct {
+	sourceLine
+	receivedAt time.Time
+}
+
+// sourcesMerger keeps the streamed lines to display them in timestamp order
+type sourcesMerger struct {
+	displayOpts []DisplayOpts
+	pending     []receivedLine
+}
+
+func (m *sourcesMerger) add(line sourceLine, receivedAt time.Time) {
+	m.pending = append(m.pending, receivedLine{sourceLine: line, receivedAt: receivedAt})
+}
+
+// flush displays the lines returned by ready
+func (m *sourcesMerger) flush(receivedBefore time.Time) {
+	for _, line := range m.ready(receivedBefore) {
+		displayLogs(line.line, m.displayOpts[line.source])
+	}
+}
+
+// ready removes from the pending lines and returns in timestamp order the
+// lines received before receivedBefore, or all the lines if receivedBefore is
+// zero
+func (m *sourcesMerger) ready(receivedBefore time.Time) []receivedLine {
+	var ready, pending []receivedLine
+	for _, line := range m.pending {
+		if receivedBefore.IsZero() || !line.receivedAt.After(receivedBefore) {
+			ready = append(ready, line)
+		} else {
+			pending = append(pending, line)
+		}
+	}
+	m.pending = pending
+
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].t.Before(ready[j].t)
+	})
+	return ready
+}
@@ -0,0 +1,50 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimedSourceLines(t *testing.T) {
+	lines := timedSourceLines([]string{
+		"2026-10-10 14:00:05 +0000 UTC [web-1] panic: boom",
+		"goroutine 1 [running]:",
+		"2026-10-10 14:00:06 +0000 UTC [web-1] restarting",
+	}, 2)
+	require.Len(t, lines, 3)
+
+	first := time.Date(2026, 10, 10, 14, 0, 5, 0, time.UTC)
+	assert.True(t, first.Equal(lines[0].t))
+	assert.True(t, first.Equal(lines[1].t))
+	assert.True(t, first.Add(time.Second).Equal(lines[2].t))
+	for _, line := range lines {
+		assert.Equal(t, 2, line.source)
+	}
+}
+
+func TestSourcesMerger_Ready(t *testing.T) {
+	now := time.Date(2026, 10, 10, 14, 0, 10, 0, time.UTC)
+	merger := &sourcesMerger{}
+	merger.add(sourceLine{t: now.Add(-2 * time.Second), line: "api", source: 0}, now.Add(-1500*time.Millisecond))
+	merger.add(sourceLine{t: now.Add(-3 * time.Second), line: "postgresql", source: 1}, now.Add(-1200*time.Millisecond))
+	merger.add(sourceLine{t: now.Add(-4 * time.Second), line: "worker", source: 2}, now)
+
+	ready := merger.ready(now.Add(-time.Second))
+	require.Len(t, ready, 2)
+	assert.Equal(t, "postgresql", ready[0].line)
+	assert.Equal(t, "api", ready[1].line)
+
+	ready = merger.ready(time.Time{})
+	require.Len(t, ready, 1)
+	assert.Equal(t, "worker", ready[0].line)
+	assert.Empty(t, merger.pending)
+}
+
+func TestDisplayOpts_SourcePrefix(t *testing.T) {
+	assert.Equal(t, "", DisplayOpts{}.sourcePrefix())
+	assert.Equal(t, "[api/postgresql] ", DisplayOpts{Raw: true, source: "api/postgresql"}.sourcePrefix())
+	assert.Contains(t, DisplayOpts{source: "api"}.sourcePrefix(), "[api]")
+}
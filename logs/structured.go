@@ -0,0 +1,287 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	errgo "gopkg.in/errgo.v1"
+)
+
+// DisplayOpts are the options of the display of the log lines
+type DisplayOpts struct {
+	// JSONFields are the fields displayed for the structured log lines
+	JSONFields []string
+	// Where are the conditions the fields of a log line must match to be
+	// displayed
+	Where []Condition
+	// Raw displays the log lines as they are received
+	Raw bool
+	// RouterStats aggregates the router log lines instead of displaying them
+	RouterStats *RouterStats
+
+	// source is the name of the source of the lines when the logs of several
+	// sources are merged
+	source string
+}
+
+// Validate returns an error if the options can't be used together
+func (opts DisplayOpts) Validate() error {
+	if opts.Raw && len(opts.JSONFields) > 0 {
+		return errgo.New("the raw output and the JSON fields can't be used together")
+	}
+	if opts.RouterStats != nil && (opts.Raw || len(opts.JSONFields) > 0) {
+		return errgo.New("the router statistics don't display the log lines, they can't be used with the raw output or the JSON fields")
+	}
+	return nil
+}
+
+type Operator string
+
+const (
+	OperatorEqual          Operator = "="
+	OperatorNotEqual       Operator = "!="
+	OperatorGreater        Operator = ">"
+	OperatorGreaterOrEqual Operator = ">="
+	OperatorLower          Operator = "<"
+	OperatorLowerOrEqual   Operator = "<="
+	OperatorMatch          Operator = "~"
+)
+
+// operators are sorted so that the two characters operators are tried first
+var operators = []Operator{
+	OperatorNotEqual, OperatorGreaterOrEqual, OperatorLowerOrEqual,
+	OperatorEqual, OperatorGreater, OperatorLower, OperatorMatch,
+}
+
+// Condition is a condition on a field of the structured log lines, e.g.
+// 'status>=500'. Nested fields are separated with dots, e.g. 'http.status'.
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    string
+
+	re *regexp.Regexp
+}
+
+// ParseWhere parses a condition like 'level=error', 'status>=500' or
+// 'msg~timeout'
+func ParseWhere(expr string) (Condition, error) {
+	i := strings.IndexAny(expr, "=!<>~")
+	if i <= 0 {
+		return Condition{}, errgo.Newf("invalid condition '%s', it should be like 'field=value'", expr)
+	}
+
+	condition := Condition{Field: strings.TrimSpace(expr[:i])}
+	for _, operator := range operators {
+		if strings.HasPrefix(expr[i:], string(operator)) {
+			condition.Operator = operator
+			condition.Value = strings.TrimSpace(expr[i+len(operator):])
+			break
+		}
+	}
+	if condition.Operator == "" {
+		return Condition{}, errgo.Newf("invalid operator in condition '%s'", expr)
+	}
+
+	if condition.Operator == OperatorMatch {
+		re, err := regexp.Compile(condition.Value)
+		if err != nil {
+			return Condition{}, errgo.Notef(err, "invalid regular expression in condition '%s'", expr)
+		}
+		condition.re = re
+	}
+	return condition, nil
+}
+
+// ParseWheres parses the given conditions, a log line must match all of them
+func ParseWheres(exprs []string) ([]Condition, error) {
+	conditions := make([]Condition, 0, len(exprs))
+	for _, expr := range exprs {
+		condition, err := ParseWhere(expr)
+		if err != nil {
+			return nil, errgo.Mask(err)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// Match returns true if the field of the condition is in fields and its value
+// matches the condition
+func (c Condition) Match(fields map[string]interface{}) bool {
+	field, ok := lookupField(fields, c.Field)
+	if !ok {
+		return false
+	}
+	value := fieldString(field)
+
+	if c.Operator == OperatorMatch {
+		return c.re.MatchString(value)
+	}
+
+	var cmp int
+	number, errValue := strconv.ParseFloat(value, 64)
+	expected, errExpected := strconv.ParseFloat(c.Value, 64)
+	if errValue == nil && errExpected == nil {
+		switch {
+		case number < expected:
+			cmp = -1
+		case number > expected:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(value, c.Value)
+	}
+
+	switch c.Operator {
+	case OperatorEqual:
+		return cmp == 0
+	case OperatorNotEqual:
+		return cmp != 0
+	case OperatorGreater:
+		return cmp > 0
+	case OperatorGreaterOrEqual:
+		return cmp >= 0
+	case OperatorLower:
+		return cmp < 0
+	case OperatorLowerOrEqual:
+		return cmp <= 0
+	}
+	return false
+}
+
+func matchAll(conditions []Condition, fields map[string]interface{}) bool {
+	if fields == nil {
+		return false
+	}
+	for _, condition := range conditions {
+		if !condition.Match(fields) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFields returns the fields of a structured log content: a JSON object
+// or a list of key=value pairs like the router logs. It returns nil if the
+// content is not structured.
+func parseFields(content string) map[string]interface{} {
+	content = strings.TrimSpace(content)
+	if strings.HasPrefix(content, "{") {
+		decoder := json.NewDecoder(strings.NewReader(content))
+		decoder.UseNumber()
+		var fields map[string]interface{}
+		if decoder.Decode(&fields) == nil {
+			return fields
+		}
+		return nil
+	}
+	return parseKeyValues(content)
+}
+
+// parseKeyValues parses 'key=value key="quoted value"' contents. It returns
+// nil if one of the words is not a key=value pair.
+func parseKeyValues(content string) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for content != "" {
+		equal := strings.Index(content, "=")
+		if equal <= 0 || strings.ContainsAny(content[:equal], " \"") {
+			return nil
+		}
+		key := content[:equal]
+		content = content[equal+1:]
+
+		var value string
+		if strings.HasPrefix(content, `"`) {
+			end := 1
+			for end < len(content) && content[end] != '"' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(content) {
+				return nil
+			}
+			unquoted, err := strconv.Unquote(content[:end+1])
+			if err != nil {
+				unquoted = content[1:end]
+			}
+			value = unquoted
+			content = content[end+1:]
+		} else {
+			end := strings.Index(content, " ")
+			if end == -1 {
+				end = len(content)
+			}
+			value = content[:end]
+			content = content[end:]
+		}
+		fields[key] = value
+		content = strings.TrimLeft(content, " ")
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+func lookupField(fields map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := fields[name]; ok {
+		return value, true
+	}
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) != 2 {
+		return nil, false
+	}
+	nested, ok := fields[parts[0]].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return lookupField(nested, parts[1])
+}
+
+func fieldString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case json.Number, bool:
+		return fmt.Sprint(v)
+	default:
+		buffer := &bytes.Buffer{}
+		encoder := json.NewEncoder(buffer)
+		encoder.SetEscapeHTML(false)
+		if encoder.Encode(v) != nil {
+			return fmt.Sprint(v)
+		}
+		return strings.TrimSpace(buffer.String())
+	}
+}
+
+// formatFields displays the requested fields as 'key=value' pairs
+func formatFields(fields map[string]interface{}, names []string) string {
+	keyColor := color.New(color.FgGreen).SprintFunc()
+	var formatted []string
+	for _, name := range names {
+		value, ok := lookupField(fields, name)
+		if !ok {
+			continue
+		}
+		s := fieldString(value)
+		if s == "" || strings.ContainsAny(s, " \"") {
+			s = strconv.Quote(s)
+		}
+		if name == "level" || name == "severity" {
+			s = errorHighlight(s)
+		}
+		formatted = append(formatted, keyColor(name)+"="+s)
+	}
+	return strings.Join(formatted, " ")
+}
@@ -0,0 +1,142 @@
+package logs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWhere(t *testing.T) {
+	tests := map[string]struct {
+		expr              string
+		expectedCondition Condition
+		expectedError     string
+	}{
+		"an equality": {
+			expr:              "level=error",
+			expectedCondition: Condition{Field: "level", Operator: OperatorEqual, Value: "error"},
+		},
+		"a two characters operator": {
+			expr:              "status>=500",
+			expectedCondition: Condition{Field: "status", Operator: OperatorGreaterOrEqual, Value: "500"},
+		},
+		"a difference with spaces": {
+			expr:              "http.method != GET",
+			expectedCondition: Condition{Field: "http.method", Operator: OperatorNotEqual, Value: "GET"},
+		},
+		"a value with an operator": {
+			expr:              "msg=a=b",
+			expectedCondition: Condition{Field: "msg", Operator: OperatorEqual, Value: "a=b"},
+		},
+		"no field": {
+			expr:          "=error",
+			expectedError: "invalid condition '=error'",
+		},
+		"no operator": {
+			expr:          "level",
+			expectedError: "invalid condition 'level'",
+		},
+		"an invalid regular expression": {
+			expr:          "msg~(",
+			expectedError: "invalid regular expression",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			condition, err := ParseWhere(test.expr)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCondition, condition)
+		})
+	}
+}
+
+func TestCondition_Match(t *testing.T) {
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(`{"level":"error","status":503,"http":{"method":"GET"},"msg":"request timeout"}`))
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&fields))
+
+	tests := map[string]bool{
+		"level=error":     true,
+		"level!=error":    false,
+		"status>=500":     true,
+		"status>1000":     false,
+		"status<600":      true,
+		"status<=503":     true,
+		"status=503.0":    true,
+		"http.method=GET": true,
+		"http.path=/":     false,
+		"msg~time(out)?":  true,
+		"msg~^timeout":    false,
+		"missing=":        false,
+		"level>debug":     true,
+	}
+
+	for expr, expected := range tests {
+		t.Run(expr, func(t *testing.T) {
+			condition, err := ParseWhere(expr)
+			require.NoError(t, err)
+			assert.Equal(t, expected, condition.Match(fields))
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := map[string]struct {
+		content        string
+		expectedFields map[string]interface{}
+	}{
+		"a JSON object": {
+			content:        `{"level":"info","count":2}`,
+			expectedFields: map[string]interface{}{"level": "info", "count": json.Number("2")},
+		},
+		"an invalid JSON object": {
+			content: `{"level":`,
+		},
+		"key=value pairs": {
+			content:        `method=GET path="/a b" status=200 protocol=http`,
+			expectedFields: map[string]interface{}{"method": "GET", "path": "/a b", "status": "200", "protocol": "http"},
+		},
+		"key=value pairs with an escaped quote": {
+			content:        `msg="say \"hi\"" level=info`,
+			expectedFields: map[string]interface{}{"msg": `say "hi"`, "level": "info"},
+		},
+		"a text": {
+			content: "Listening on port 8080",
+		},
+		"a text with a key=value pair": {
+			content: "Starting with PORT=8080",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedFields, parseFields(test.content))
+		})
+	}
+}
+
+func TestFormatFields(t *testing.T) {
+	color.NoColor = true
+	fields := map[string]interface{}{
+		"level":      "error",
+		"msg":        "connection refused",
+		"request_id": "abc",
+		"http":       map[string]interface{}{"status": json.Number("502")},
+	}
+
+	assert.Equal(t,
+		`level=error msg="connection refused" http.status=502`,
+		formatFields(fields, []string{"level", "msg", "missing", "http.status"}),
+	)
+}
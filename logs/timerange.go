@@ -0,0 +1,512 @@
+package logs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	stdio "io"
+
+	errgo "gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+	"github.com/Scalingo/go-scalingo/v6/debug"
+)
+
+// lineTimestampLayout is the layout of the date at the beginning of the log
+// lines, e.g. '2026-10-10 14:00:00.123456789 +0000 UTC'
+const lineTimestampLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+var daysDurationRe = regexp.MustCompile(`^([0-9]+)d(.*)$`)
+
+// TimeRange is the time range of a logs query. A zero Until means now.
+type TimeRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// IsZero returns true if no time bound is set
+func (r TimeRange) IsZero() bool {
+	return r.Since.IsZero() && r.Until.IsZero()
+}
+
+// Contains returns true if t is in the time range, bounds included
+func (r TimeRange) Contains(t time.Time) bool {
+	if !r.Since.IsZero() && t.Before(r.Since) {
+		return false
+	}
+	if !r.Until.IsZero() && t.After(r.Until) {
+		return false
+	}
+	return true
+}
+
+// overlaps returns true if the period between from and to intersects the
+// time range
+func (r TimeRange) overlaps(from, to time.Time) bool {
+	if !r.Since.IsZero() && to.Before(r.Since) {
+		return false
+	}
+	if !r.Until.IsZero() && from.After(r.Until) {
+		return false
+	}
+	return true
+}
+
+// ParseTimeRange parses the bounds of a time range. A bound is either a date
+// ('2026-10-10T14:00', '2026-10-10 14:00:00', RFC 3339…), a time of the day
+// ('14:30') or a duration before now ('2h', '7d'). A time of the day is on the date
+// of since for until, and on the current date otherwise. The dates without
+// time zone are in the local time zone.
+func ParseTimeRange(since, until string, now time.Time) (TimeRange, error) {
+	var (
+		r   TimeRange
+		err error
+	)
+	if since != "" {
+		r.Since, err = parseTimeBound(since, now, now)
+		if err != nil {
+			return r, errgo.Notef(err, "invalid --since")
+		}
+	}
+	if until != "" {
+		day := now
+		if !r.Since.IsZero() {
+			day = r.Since
+		}
+		r.Until, err = parseTimeBound(until, day, now)
+		if err != nil {
+			return r, errgo.Notef(err, "invalid --until")
+		}
+	}
+	if !r.Since.IsZero() && !r.Until.IsZero() && r.Until.Before(r.Since) {
+		return r, errgo.Newf("--until (%v) is before --since (%v)", r.Until, r.Since)
+	}
+	return r, nil
+}
+
+func parseTimeBound(value string, day, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if d, err := parseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, day.Location()); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.ParseInLocation(layout, value, day.Location()); err == nil {
+			year, month, date := day.Date()
+			return time.Date(year, month, date, t.Hour(), t.Minute(), t.Second(), 0, day.Location()), nil
+		}
+	}
+	return time.Time{}, errgo.Newf("'%s' is not a date, a time or a duration (e.g. 2026-10-10T14:00, 14:30 or 2h)", value)
+}
+
+// parseDuration parses a duration like time.ParseDuration, with days in
+// addition, e.g. '7d' or '1d12h'
+func parseDuration(value string) (time.Duration, error) {
+	match := daysDurationRe.FindStringSubmatch(value)
+	if match == nil {
+		return time.ParseDuration(value)
+	}
+	days, _ := strconv.Atoi(match[1])
+	d := time.Duration(days) * 24 * time.Hour
+	if match[2] != "" {
+		rest, err := time.ParseDuration(match[2])
+		if err != nil {
+			return 0, err
+		}
+		d += rest
+	}
+	return d, nil
+}
+
+// lineTimestamp returns the date at the beginning of a log line
+func lineTimestamp(line string) (time.Time, bool) {
+	split := strings.SplitN(line, " ", 5)
+	if len(split) < 5 {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(lineTimestampLayout, strings.Join(split[:4], " "))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// matchContainerFilter returns true if the container of the log line matches
+// the filter of the logs command, e.g. 'web|worker-1'
+func matchContainerFilter(line, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	split := strings.SplitN(line, " ", 6)
+	if len(split) < 5 {
+		return false
+	}
+	container := strings.Trim(split[4], "[]")
+	for _, f := range strings.Split(filter, "|") {
+		if container == f || strings.HasPrefix(container, f+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchivesPager returns the successive pages of logs archives. It returns nil
+// when there is no more page.
+type ArchivesPager func(ctx context.Context) (*scalingo.LogsArchivesResponse, error)
+
+// AppArchivesPager returns the pages of the logs archives of an app
+func AppArchivesPager(c scalingo.LogsArchivesService, app string) ArchivesPager {
+	var previous *scalingo.LogsArchivesResponse
+	return func(ctx context.Context) (*scalingo.LogsArchivesResponse, error) {
+		var (
+			res *scalingo.LogsArchivesResponse
+			err error
+		)
+		if previous == nil {
+			res, err = c.LogsArchives(ctx, app, 1)
+		} else if previous.HasMore && previous.NextCursor != "" {
+			res, err = c.LogsArchivesByCursor(ctx, app, previous.NextCursor)
+		} else {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to get the logs archives")
+		}
+		previous = res
+		return res, nil
+	}
+}
+
+// AddonArchivesPager returns the pages of the logs archives of an addon
+func AddonArchivesPager(c scalingo.AddonsService, app, addonID string) ArchivesPager {
+	page := 0
+	hasMore := true
+	return func(ctx context.Context) (*scalingo.LogsArchivesResponse, error) {
+		if !hasMore {
+			return nil, nil
+		}
+		page++
+		res, err := c.AddonLogsArchives(ctx, app, addonID, page)
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to get the addon logs archives")
+		}
+		hasMore = res.HasMore && len(res.Archives) > 0
+		return res, nil
+	}
+}
+
+type archive struct {
+	scalingo.LogsArchiveItem
+	from time.Time
+	to   time.Time
+}
+
+// archivesInTimeRange returns the archives overlapping the time range, from
+// the oldest to the most recent. The archives are listed from the most recent
+// one, the listing stops at the first page older than the time range.
+func archivesInTimeRange(ctx context.Context, pager ArchivesPager, r TimeRange) ([]archive, error) {
+	var archives []archive
+	for {
+		res, err := pager(ctx)
+		if err != nil {
+			return nil, errgo.Mask(err, errgo.Any)
+		}
+		if res == nil || len(res.Archives) == 0 {
+			break
+		}
+
+		// The archives without valid bounds are ignored to decide whether the
+		// page is older than the time range
+		olderPage, hasBounds := true, false
+		for _, item := range res.Archives {
+			a := archive{LogsArchiveItem: item}
+			from, errFrom := parseArchiveTime(item.From)
+			to, errTo := parseArchiveTime(item.To)
+			if errFrom != nil || errTo != nil {
+				// Without valid bounds, the lines of the archive are checked one by one
+				debug.Printf("[logs] Invalid bounds for archive %v: %v, %v\n", item.URL, errFrom, errTo)
+				archives = append(archives, a)
+				continue
+			}
+			a.from, a.to = from, to
+			hasBounds = true
+			if r.Since.IsZero() || !to.Before(r.Since) {
+				olderPage = false
+			}
+			if r.overlaps(from, to) {
+				archives = append(archives, a)
+			}
+		}
+		if olderPage && hasBounds {
+			break
+		}
+	}
+
+	sort.SliceStable(archives, func(i, j int) bool {
+		return archives[i].from.Before(archives[j].from)
+	})
+	return archives, nil
+}
+
+func parseArchiveTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err == nil {
+		return t, nil
+	}
+	return time.Parse(lineTimestampLayout, value)
+}
+
+// openArchive downloads a logs archive and returns the reader of the
+// decompressed log lines
+func openArchive(ctx context.Context, url string) (stdio.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errgo.Notef(err, "invalid archive URL")
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to download the archive")
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, errgo.Newf("fail to download the archive: %s", res.Status)
+	}
+	gz, err := gzip.NewReader(res.Body)
+	if err != nil {
+		res.Body.Close()
+		return nil, errgo.Notef(err, "fail to decompress the archive")
+	}
+	return &archiveReader{Reader: gz, body: res.Body}, nil
+}
+
+type archiveReader struct {
+	*gzip.Reader
+	body stdio.ReadCloser
+}
+
+func (r *archiveReader) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
+}
+
+// eachLine calls fn with each line of r, without the trailing new line
+func eachLine(r stdio.Reader, fn func(line string)) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" {
+			fn(line)
+		}
+		if err == stdio.EOF {
+			return nil
+		}
+		if err != nil {
+			return errgo.Notef(err, "fail to read logs")
+		}
+	}
+}
+
+// logMessage is a log line with the following lines without timestamp, like
+// the lines of a multi-line message. They share the timestamp of the first
+// line to stay after it.
+type logMessage struct {
+	t     time.Time
+	lines []string
+}
+
+// timedMessages groups the lines into messages
+func timedMessages(lines []string) []logMessage {
+	var messages []logMessage
+	for _, line := range lines {
+		if t, ok := lineTimestamp(line); ok || len(messages) == 0 {
+			messages = append(messages, logMessage{t: t, lines: []string{line}})
+			continue
+		}
+		last := &messages[len(messages)-1]
+		last.lines = append(last.lines, line)
+	}
+	return messages
+}
+
+// eachMessage calls fn with each message of r
+func eachMessage(r stdio.Reader, fn func(m logMessage)) error {
+	var current *logMessage
+	err := eachLine(r, func(line string) {
+		t, ok := lineTimestamp(line)
+		if current != nil && !ok {
+			current.lines = append(current.lines, line)
+			return
+		}
+		if current != nil {
+			fn(*current)
+		}
+		current = &logMessage{t: t, lines: []string{line}}
+	})
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+	if current != nil {
+		fn(*current)
+	}
+	return nil
+}
+
+// timeRangeWriter displays the log messages of a time range in timestamp
+// order. The messages already displayed from an archive are skipped when they
+// are also in the recent logs.
+type timeRangeWriter struct {
+	timeRange TimeRange
+	filter    string
+	pattern   *regexp.Regexp
+	display   DisplayOpts
+
+	last         time.Time
+	lastMessages map[string]bool
+	linesWritten int
+}
+
+// matches returns true if the message is in the time range and matches the
+// container filter and the pattern. The pattern may match any line of the
+// message.
+func (w *timeRangeWriter) matches(m logMessage) bool {
+	if !w.timeRange.Contains(m.t) || !matchContainerFilter(m.lines[0], w.filter) {
+		return false
+	}
+	if w.pattern == nil {
+		return true
+	}
+	for _, line := range m.lines {
+		if w.pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *timeRangeWriter) write(m logMessage) {
+	if !w.matches(m) {
+		return
+	}
+	key := strings.Join(m.lines, "\n")
+	if m.t.Before(w.last) || (m.t.Equal(w.last) && w.lastMessages[key]) {
+		return
+	}
+	if m.t.After(w.last) {
+		w.last = m.t
+		w.lastMessages = map[string]bool{}
+	}
+	w.lastMessages[key] = true
+	for _, line := range m.lines {
+		w.linesWritten++
+		displayLogs(line, w.display)
+	}
+}
+
+// writeArchives displays the messages of the archives in the time range
+func (w *timeRangeWriter) writeArchives(ctx context.Context, archives []archive) error {
+	for _, a := range archives {
+		debug.Printf("[logs] Read archive from %v to %v\n", a.From, a.To)
+		r, err := openArchive(ctx, a.URL)
+		if err != nil {
+			return errgo.Notef(err, "fail to read the archive from %v to %v", a.From, a.To)
+		}
+		// Only the messages of the time range are kept in memory
+		var messages []logMessage
+		err = eachMessage(r, func(m logMessage) {
+			if w.timeRange.Contains(m.t) {
+				messages = append(messages, m)
+			}
+		})
+		r.Close()
+		if err != nil {
+			return errgo.Notef(err, "fail to read the archive from %v to %v", a.From, a.To)
+		}
+		w.writeSorted(messages)
+	}
+	return nil
+}
+
+// writeSorted displays the messages sorted by timestamp
+func (w *timeRangeWriter) writeSorted(messages []logMessage) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].t.Before(messages[j].t)
+	})
+	for _, m := range messages {
+		w.write(m)
+	}
+}
+
+// DumpTimeRange displays the log lines of the time range. The lines come from
+// the logs archives and from the recent logs, they are displayed in timestamp
+// order.
+func DumpTimeRange(ctx context.Context, logsURL string, filter string, timeRange TimeRange, archivesPager ArchivesPager, opts DisplayOpts) error {
+	archives, err := archivesInTimeRange(ctx, archivesPager, timeRange)
+	if err != nil {
+		return errgo.Notef(err, "fail to list the logs archives")
+	}
+
+	w := &timeRangeWriter{timeRange: timeRange, filter: filter, display: opts}
+	err = w.writeArchives(ctx, archives)
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+
+	// The recent logs are only useful if the time range ends after the last
+	// archive
+	if timeRange.Until.IsZero() || len(archives) == 0 || archives[len(archives)-1].to.Before(timeRange.Until) {
+		lines, err := recentLines(ctx, logsURL, filter)
+		if err != nil {
+			return errgo.Mask(err, errgo.Any)
+		}
+		w.writeSorted(timedMessages(lines))
+	}
+
+	if w.linesWritten == 0 {
+		io.Info("There is no log in this time range")
+	}
+	return nil
+}
+
+// recentLines returns the most recent log lines
+func recentLines(ctx context.Context, logsURL string, filter string) ([]string, error) {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	res, err := c.Logs(ctx, logsURL, logsMaxBufferSize, filter)
+	if err != nil {
+		return nil, errgo.Mask(err, errgo.Any)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == 404 || res.StatusCode == 204 {
+		return nil, nil
+	}
+
+	var lines []string
+	err = eachLine(res.Body, func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		return nil, errgo.Mask(err, errgo.Any)
+	}
+	return lines, nil
+}
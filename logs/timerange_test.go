@@ -0,0 +1,199 @@
+package logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, paris)
+
+	tests := map[string]struct {
+		since         string
+		until         string
+		expectedRange TimeRange
+		expectedError string
+	}{
+		"no bound": {},
+		"a date and a time of the day": {
+			since: "2026-10-10T14:00",
+			until: "14:30",
+			expectedRange: TimeRange{
+				Since: time.Date(2026, 10, 10, 14, 0, 0, 0, paris),
+				Until: time.Date(2026, 10, 10, 14, 30, 0, 0, paris),
+			},
+		},
+		"a RFC 3339 date": {
+			since:         "2026-10-10T14:00:00Z",
+			expectedRange: TimeRange{Since: time.Date(2026, 10, 10, 14, 0, 0, 0, time.UTC)},
+		},
+		"a date with a space": {
+			since:         "2026-10-10 14:00:05",
+			expectedRange: TimeRange{Since: time.Date(2026, 10, 10, 14, 0, 5, 0, paris)},
+		},
+		"a duration": {
+			since:         "2h",
+			expectedRange: TimeRange{Since: now.Add(-2 * time.Hour)},
+		},
+		"a duration in days": {
+			since:         "1d12h",
+			expectedRange: TimeRange{Since: now.Add(-36 * time.Hour)},
+		},
+		"a time of the day without since": {
+			until:         "08:00",
+			expectedRange: TimeRange{Until: time.Date(2026, 10, 18, 8, 0, 0, 0, paris)},
+		},
+		"an invalid bound": {
+			since:         "yesterday",
+			expectedError: "invalid --since",
+		},
+		"until before since": {
+			since:         "14:00",
+			until:         "13:00",
+			expectedError: "is before --since",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := ParseTimeRange(test.since, test.until, now)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.expectedRange.Since.Equal(r.Since), "since: %v", r.Since)
+			assert.True(t, test.expectedRange.Until.Equal(r.Until), "until: %v", r.Until)
+		})
+	}
+}
+
+func TestLineTimestamp(t *testing.T) {
+	ts, ok := lineTimestamp("2026-10-10 14:00:00.123456789 +0000 UTC [web-1] Listening")
+	require.True(t, ok)
+	assert.True(t, time.Date(2026, 10, 10, 14, 0, 0, 123456789, time.UTC).Equal(ts))
+
+	ts, ok = lineTimestamp("2026-10-10 14:00:00 +0200 CEST [router] method=GET")
+	require.True(t, ok)
+	assert.True(t, time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC).Equal(ts))
+
+	_, ok = lineTimestamp("a line without date")
+	assert.False(t, ok)
+}
+
+func TestMatchContainerFilter(t *testing.T) {
+	line := "2026-10-10 14:00:00 +0000 UTC [worker-2] Job done"
+
+	assert.True(t, matchContainerFilter(line, ""))
+	assert.True(t, matchContainerFilter(line, "worker"))
+	assert.True(t, matchContainerFilter(line, "web|worker-2"))
+	assert.False(t, matchContainerFilter(line, "worker-1"))
+	assert.False(t, matchContainerFilter(line, "work"))
+}
+
+func TestTimedMessages(t *testing.T) {
+	messages := timedMessages([]string{
+		"2026-10-10 14:00:00 +0000 UTC [web-1] panic: boom",
+		"goroutine 1 [running]:",
+		"main.main()",
+		"2026-10-10 14:00:01 +0000 UTC [web-1] Restarting",
+	})
+
+	require.Len(t, messages, 2)
+	assert.True(t, time.Date(2026, 10, 10, 14, 0, 0, 0, time.UTC).Equal(messages[0].t))
+	assert.Equal(t, []string{
+		"2026-10-10 14:00:00 +0000 UTC [web-1] panic: boom",
+		"goroutine 1 [running]:",
+		"main.main()",
+	}, messages[0].lines)
+	assert.Equal(t, []string{"2026-10-10 14:00:01 +0000 UTC [web-1] Restarting"}, messages[1].lines)
+}
+
+func TestArchivesInTimeRange(t *testing.T) {
+	pages := []*scalingo.LogsArchivesResponse{
+		{HasMore: true, Archives: []scalingo.LogsArchiveItem{
+			{URL: "4", From: "2026-10-10T16:00:00Z", To: "2026-10-10T17:00:00Z"},
+			{URL: "3", From: "2026-10-10T15:00:00Z", To: "2026-10-10T16:00:00Z"},
+		}},
+		{HasMore: true, Archives: []scalingo.LogsArchiveItem{
+			{URL: "2", From: "2026-10-10T14:00:00Z", To: "2026-10-10T15:00:00Z"},
+			{URL: "1", From: "2026-10-10T13:00:00Z", To: "2026-10-10T14:00:00Z"},
+		}},
+		{HasMore: true, Archives: []scalingo.LogsArchiveItem{
+			{URL: "invalid", From: "unknown", To: "unknown"},
+			{URL: "0", From: "2026-10-10T12:00:00Z", To: "2026-10-10T13:00:00Z"},
+		}},
+		{HasMore: false, Archives: []scalingo.LogsArchiveItem{
+			{URL: "never listed", From: "2026-10-10T11:00:00Z", To: "2026-10-10T12:00:00Z"},
+		}},
+	}
+	requestedPages := 0
+	pager := func(ctx context.Context) (*scalingo.LogsArchivesResponse, error) {
+		if requestedPages == len(pages) {
+			return nil, nil
+		}
+		requestedPages++
+		return pages[requestedPages-1], nil
+	}
+
+	archives, err := archivesInTimeRange(context.Background(), pager, TimeRange{
+		Since: time.Date(2026, 10, 10, 14, 10, 0, 0, time.UTC),
+		Until: time.Date(2026, 10, 10, 15, 30, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	var urls []string
+	for _, a := range archives {
+		urls = append(urls, a.URL)
+	}
+	assert.Equal(t, []string{"invalid", "2", "3"}, urls)
+	assert.Equal(t, 3, requestedPages)
+}
+
+func TestOpenArchive(t *testing.T) {
+	content := "2026-10-10 14:00:00 +0000 UTC [web-1] first\n2026-10-10 14:00:01 +0000 UTC [web-1] second\n"
+	buffer := &bytes.Buffer{}
+	gz := gzip.NewWriter(buffer)
+	_, err := gz.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/archive.gz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(buffer.Bytes())
+	}))
+	defer server.Close()
+
+	r, err := openArchive(context.Background(), server.URL+"/archive.gz")
+	require.NoError(t, err)
+	defer r.Close()
+
+	var lines []string
+	err = eachLine(r, func(line string) {
+		lines = append(lines, line)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"2026-10-10 14:00:00 +0000 UTC [web-1] first",
+		"2026-10-10 14:00:01 +0000 UTC [web-1] second",
+	}, lines)
+
+	_, err = openArchive(context.Background(), server.URL+"/missing.gz")
+	assert.ErrorContains(t, err, "404")
+}
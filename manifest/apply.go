@@ -0,0 +1,204 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+type ApplyOpts struct {
+	// App overrides the app of the manifest
+	App string
+	// Prune deletes the resources which are missing from the manifest sections
+	Prune bool
+	// Yes applies the changes without asking for a confirmation
+	Yes bool
+	// DryRun only displays the changes
+	DryRun bool
+}
+
+// Apply converges an app to the state described by the manifest
+func Apply(ctx context.Context, path string, opts ApplyOpts) error {
+	m, err := Load(path)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	err = validate(m)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	app := m.App
+	if opts.App != "" {
+		app = opts.App
+	}
+	if app == "" {
+		return errgo.New("no app defined, set 'app' in the manifest or use the --app flag")
+	}
+	// The app may only be known from the manifest, the consent is checked once
+	// it is resolved
+	utils.CheckForConsent(ctx, app)
+
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	live, err := fetchState(ctx, c, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current state of %s", app)
+	}
+
+	p := newPlan(m, live, opts.Prune)
+	p.display()
+	if p.isEmpty() || opts.DryRun {
+		return nil
+	}
+
+	if !opts.Yes {
+		io.Info("Do you want to apply these changes? (y/N)")
+		var confirm string
+		fmt.Scanln(&confirm)
+		if confirm != "y" && confirm != "Y" {
+			return errgo.New("You didn't confirm, aborting…")
+		}
+	}
+
+	err = p.apply(ctx, c)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("The manifest has been applied to %s\n", app)
+	return nil
+}
+
+// apply executes the changes in order and stops at the first failure
+func (p plan) apply(ctx context.Context, c *scalingo.Client) error {
+	scaled, variablesSet := false, false
+	for _, change := range p.changes {
+		name := change.resource + "." + change.name
+		if change.variable != nil {
+			if variablesSet {
+				continue
+			}
+			err := p.setVariables(ctx, c)
+			if err != nil {
+				return errgo.Notef(err, "fail to set the environment variables of %s", p.app)
+			}
+			variablesSet = true
+			continue
+		}
+		if change.scale != nil {
+			if scaled {
+				continue
+			}
+			err := p.scale(ctx, c)
+			if err != nil {
+				return errgo.Notef(err, "fail to scale %s", p.app)
+			}
+			scaled = true
+			continue
+		}
+
+		err := change.apply(ctx, c)
+		if err != nil {
+			return errgo.Notef(err, "fail to %s %s", change.action, name)
+		}
+		io.Infof("%s: %sd\n", name, change.action)
+	}
+	return nil
+}
+
+// setVariables sets all the environment variables with a single request
+func (p plan) setVariables(ctx context.Context, c *scalingo.Client) error {
+	var variables scalingo.Variables
+	var changes []change
+	for _, change := range p.changes {
+		if change.variable != nil {
+			variables = append(variables, change.variable)
+			changes = append(changes, change)
+		}
+	}
+	_, _, err := c.VariableMultipleSet(ctx, p.app, variables)
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+
+	for _, change := range changes {
+		io.Infof("%s.%s: %sd\n", change.resource, change.name, change.action)
+	}
+	return nil
+}
+
+// scale applies all the formation changes with a single scaling operation
+func (p plan) scale(ctx context.Context, c *scalingo.Client) error {
+	params := &scalingo.AppsScaleParams{}
+	for _, change := range p.changes {
+		if change.scale != nil {
+			params.Containers = append(params.Containers, *change.scale)
+		}
+	}
+	res, err := c.AppsScale(ctx, p.app, params)
+	if err != nil {
+		return errgo.Mask(err, errgo.Any)
+	}
+	res.Body.Close()
+
+	for _, container := range params.Containers {
+		io.Infof("formation.%s: scaling\n", container.Name)
+	}
+	return nil
+}
+
+func validate(m Manifest) error {
+	domains := map[string]bool{}
+	for _, d := range m.Domains {
+		if domains[d.Name] {
+			return errgo.Newf("domain %s is declared twice", d.Name)
+		}
+		domains[d.Name] = true
+	}
+
+	notifiers := map[string]bool{}
+	for _, n := range m.Notifiers {
+		if n.Name == "" || n.Type == "" {
+			return errgo.New("the name and the type of the notifiers are required")
+		}
+		if notifiers[n.Name] {
+			return errgo.Newf("notifier %s is declared twice", n.Name)
+		}
+		notifiers[n.Name] = true
+	}
+
+	alerts := map[alertKey]bool{}
+	for _, a := range m.Alerts {
+		if alerts[a.key()] {
+			return errgo.Newf("alert %s is declared twice", alertName(a))
+		}
+		alerts[a.key()] = true
+		for _, d := range []string{a.DurationBeforeTrigger, a.RemindEvery} {
+			if d == "" {
+				continue
+			}
+			if _, err := time.ParseDuration(d); err != nil {
+				return errgo.Newf("invalid duration '%s' in alert %s", d, alertName(a))
+			}
+		}
+	}
+
+	autoscalers := map[string]bool{}
+	for _, a := range m.Autoscalers {
+		if autoscalers[a.ContainerType] {
+			return errgo.Newf("autoscaler %s is declared twice", a.ContainerType)
+		}
+		autoscalers[a.ContainerType] = true
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+type CloneOpts struct {
+	// Name is the name of the new app
+	Name string
+	// Region is the region of the new app, the region of the source app is
+	// used if empty
+	Region string
+}
+
+// Clone creates a new app with the same stack, addons, environment, routing
+// settings, formation, alerts and autoscalers as the source app. The domains
+// and the notifiers are not copied, neither are the data of the addons: the
+// API has no way to restore a backup into another database.
+//
+// The formation, the alerts and the autoscalers require the container types
+// which are only known once the new app has been deployed. If it has not been
+// deployed yet, they are written to a manifest to apply after the first
+// deployment.
+func Clone(ctx context.Context, src string, opts CloneOpts) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+	dstClient := c
+	if opts.Region != "" {
+		dstClient, err = config.ScalingoClientForRegion(ctx, opts.Region)
+		if err != nil {
+			return errgo.Notef(err, "fail to get Scalingo client for region %s", opts.Region)
+		}
+	}
+
+	srcApp, err := c.AppsShow(ctx, src)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the app %s", src)
+	}
+	source, err := fetchState(ctx, c, src)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current state of %s", src)
+	}
+
+	dst, err := dstClient.AppsCreate(ctx, scalingo.AppsCreateOpts{Name: opts.Name})
+	if err != nil {
+		return errgo.Notef(err, "fail to create the app %s", opts.Name)
+	}
+	io.Statusf("App '%s' has been created\n", dst.Name)
+
+	_, err = dstClient.AppsSetStack(ctx, dst.Name, srcApp.StackID)
+	if err != nil {
+		return errgo.Notef(err, "fail to set the stack of %s", dst.Name)
+	}
+	io.Infof("stack: %s\n", srcApp.StackID)
+
+	err = cloneStep(ctx, dstClient, dst.Name, Manifest{Addons: source.Addons})
+	if err != nil {
+		return errgo.Notef(err, "fail to provision the addons")
+	}
+	err = cloneStep(ctx, dstClient, dst.Name, Manifest{Env: cloneEnv(source), Routing: source.Routing})
+	if err != nil {
+		return errgo.Notef(err, "fail to copy the environment and the routing settings")
+	}
+
+	// The notifiers are not copied, the alerts must not reference them
+	alerts := make([]Alert, 0, len(source.Alerts))
+	for _, a := range source.Alerts {
+		a.Notifiers = nil
+		alerts = append(alerts, a)
+	}
+	containers := Manifest{
+		Formation:   source.Formation,
+		Alerts:      alerts,
+		Autoscalers: source.Autoscalers,
+	}
+	live, err := fetchState(ctx, dstClient, dst.Name)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current state of %s", dst.Name)
+	}
+	if len(live.Formation) == 0 && len(source.Formation) > 0 {
+		return writeDeferredManifest(dst.Name, containers)
+	}
+	err = cloneStep(ctx, dstClient, dst.Name, containers)
+	if err != nil {
+		return errgo.Notef(err, "fail to copy the formation, the alerts and the autoscalers")
+	}
+
+	io.Statusf("%s has been cloned to %s\n", src, dst.Name)
+	return nil
+}
+
+// cloneEnv returns the environment variables to copy to the new app. The
+// variables defined by the addons of the source app are never copied, the new
+// app has the ones of its own addons. The aliases are copied as references
+// (e.g. DATABASE_URL=$SCALINGO_POSTGRESQL_URL) so that they target the new
+// addons.
+func cloneEnv(source state) map[string]string {
+	env := map[string]string{}
+	for name, value := range source.Env {
+		if !isAddonVariable(name, source.Addons) {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// cloneStep applies the sections of the manifest to the new app
+func cloneStep(ctx context.Context, c *scalingo.Client, app string, m Manifest) error {
+	live, err := fetchState(ctx, c, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current state of %s", app)
+	}
+	return newPlan(m, live, false).apply(ctx, c)
+}
+
+// writeDeferredManifest writes the sections which can only be applied once
+// the app has been deployed
+func writeDeferredManifest(app string, m Manifest) error {
+	m.App = app
+	path := app + ".scalingo.yml"
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errgo.Notef(err, "fail to open file")
+	}
+	defer f.Close()
+
+	err = write(f, m)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("%s has been created, but it has never been deployed\n", app)
+	io.Info("Its formation, alerts and autoscalers have been written to " + path + ".")
+	io.Info("Deploy the app then apply them with:")
+	fmt.Printf("\n  scalingo apply -f %s\n", path)
+	return nil
+}
@@ -0,0 +1,605 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func (p *plan) diffEnv(desired Manifest, live state, prune bool) {
+	if desired.Env == nil {
+		return
+	}
+	app := p.app
+	for _, name := range sortedKeys(desired.Env) {
+		value := desired.Env[name]
+		liveValue, exists := live.Env[name]
+		if exists && liveValue == value {
+			continue
+		}
+		c := change{action: actionCreate, resource: "env", name: name, variable: &scalingo.Variable{Name: name, Value: value}}
+		if exists {
+			c.action = actionUpdate
+			c.details = "value changed"
+		}
+		p.add(c)
+	}
+
+	if !prune {
+		return
+	}
+	for _, name := range sortedKeys(live.Env) {
+		if _, ok := desired.Env[name]; ok {
+			continue
+		}
+		id := live.variableIDs[name]
+		p.add(change{action: actionDelete, resource: "env", name: name, apply: func(ctx context.Context, client *scalingo.Client) error {
+			return client.VariableUnset(ctx, app, id)
+		}})
+	}
+}
+
+func (p *plan) diffFormation(desired Manifest, live state, prune bool) {
+	if desired.Formation == nil {
+		return
+	}
+	for _, name := range sortedKeys(desired.Formation) {
+		formation := desired.Formation[name]
+		liveFormation, exists := live.Formation[name]
+		if exists && liveFormation.Amount == formation.Amount && (formation.Size == "" || strings.EqualFold(formation.Size, liveFormation.Size)) {
+			continue
+		}
+		c := change{
+			action:   actionUpdate,
+			resource: "formation",
+			name:     name,
+			details:  fmt.Sprintf("%s → %s", formatFormation(liveFormation), formatFormation(formation)),
+			scale:    &scalingo.ContainerType{Name: name, Amount: formation.Amount, Size: formation.Size},
+		}
+		if !exists {
+			c.action = actionCreate
+			c.details = formatFormation(formation)
+		}
+		p.add(c)
+	}
+
+	if !prune {
+		return
+	}
+	for _, name := range sortedKeys(live.Formation) {
+		if _, ok := desired.Formation[name]; ok || live.Formation[name].Amount == 0 {
+			continue
+		}
+		p.add(change{
+			action:   actionUpdate,
+			resource: "formation",
+			name:     name,
+			details:  fmt.Sprintf("%s → 0", formatFormation(live.Formation[name])),
+			scale:    &scalingo.ContainerType{Name: name, Amount: 0},
+		})
+	}
+}
+
+// diffAddons never deletes an addon, it would delete its data
+func (p *plan) diffAddons(desired Manifest, live state) {
+	if desired.Addons == nil {
+		return
+	}
+	app := p.app
+	liveAddons := map[string]Addon{}
+	for _, addon := range live.Addons {
+		liveAddons[addon.Provider] = addon
+	}
+
+	desiredProviders := map[string]bool{}
+	for _, addon := range desired.Addons {
+		addon := addon
+		desiredProviders[addon.Provider] = true
+		liveAddon, exists := liveAddons[addon.Provider]
+		if !exists {
+			p.add(change{action: actionCreate, resource: "addons", name: addon.Provider, details: addon.Plan, apply: func(ctx context.Context, client *scalingo.Client) error {
+				planID, err := addonPlanID(ctx, client, addon)
+				if err != nil {
+					return err
+				}
+				_, err = client.AddonProvision(ctx, app, scalingo.AddonProvisionParams{AddonProviderID: addon.Provider, PlanID: planID})
+				return err
+			}})
+			continue
+		}
+		if liveAddon.Plan == addon.Plan {
+			continue
+		}
+		addonID := live.addonIDs[addon.Provider]
+		p.add(change{action: actionUpdate, resource: "addons", name: addon.Provider, details: fmt.Sprintf("%s → %s", liveAddon.Plan, addon.Plan), apply: func(ctx context.Context, client *scalingo.Client) error {
+			planID, err := addonPlanID(ctx, client, addon)
+			if err != nil {
+				return err
+			}
+			_, err = client.AddonUpgrade(ctx, app, addonID, scalingo.AddonUpgradeParams{PlanID: planID})
+			return err
+		}})
+	}
+
+	for _, addon := range live.Addons {
+		if !desiredProviders[addon.Provider] {
+			p.warnings = append(p.warnings, fmt.Sprintf("The %s addon is not in the manifest, addons are never removed by apply, use 'addons-remove' to remove it", addon.Provider))
+		}
+	}
+}
+
+func (p *plan) diffDomains(desired Manifest, live state, prune bool) {
+	if desired.Domains == nil {
+		return
+	}
+	app := p.app
+	liveDomains := map[string]Domain{}
+	for _, domain := range live.Domains {
+		liveDomains[domain.Name] = domain
+	}
+
+	desiredDomains := map[string]bool{}
+	hasCanonical := false
+	for _, domain := range desired.Domains {
+		desiredDomains[domain.Name] = true
+		hasCanonical = hasCanonical || domain.Canonical
+	}
+
+	for _, domain := range desired.Domains {
+		domain := domain
+		liveDomain, exists := liveDomains[domain.Name]
+		if !exists {
+			c := change{action: actionCreate, resource: "domains", name: domain.Name, apply: func(ctx context.Context, client *scalingo.Client) error {
+				d, err := client.DomainsAdd(ctx, app, scalingo.Domain{Name: domain.Name})
+				if err != nil || !domain.Canonical {
+					return err
+				}
+				_, err = client.DomainSetCanonical(ctx, app, d.ID)
+				return err
+			}}
+			if domain.Canonical {
+				c.details = "canonical"
+			}
+			p.add(c)
+			continue
+		}
+
+		if liveDomain.Canonical == domain.Canonical {
+			continue
+		}
+		id := live.domainIDs[domain.Name]
+		if domain.Canonical {
+			p.add(change{action: actionUpdate, resource: "domains", name: domain.Name, details: "set as canonical", apply: func(ctx context.Context, client *scalingo.Client) error {
+				_, err := client.DomainSetCanonical(ctx, app, id)
+				return err
+			}})
+		} else if !hasCanonical {
+			// Setting another domain as canonical already unsets this one
+			p.add(change{action: actionUpdate, resource: "domains", name: domain.Name, details: "unset as canonical", apply: func(ctx context.Context, client *scalingo.Client) error {
+				_, err := client.DomainUnsetCanonical(ctx, app)
+				return err
+			}})
+		}
+	}
+
+	if !prune {
+		return
+	}
+	for _, domain := range live.Domains {
+		if desiredDomains[domain.Name] {
+			continue
+		}
+		id := live.domainIDs[domain.Name]
+		p.add(change{action: actionDelete, resource: "domains", name: domain.Name, apply: func(ctx context.Context, client *scalingo.Client) error {
+			return client.DomainsRemove(ctx, app, id)
+		}})
+	}
+}
+
+func (p *plan) diffRouting(desired Manifest, live state) {
+	if desired.Routing == nil || live.Routing == nil {
+		return
+	}
+	app := p.app
+	settings := []struct {
+		name    string
+		desired *bool
+		live    *bool
+		apply   func(client *scalingo.Client, ctx context.Context, app string, enable bool) (*scalingo.App, error)
+	}{
+		{"force_https", desired.Routing.ForceHTTPS, live.Routing.ForceHTTPS, (*scalingo.Client).AppsForceHTTPS},
+		{"sticky_session", desired.Routing.StickySession, live.Routing.StickySession, (*scalingo.Client).AppsStickySession},
+		{"router_logs", desired.Routing.RouterLogs, live.Routing.RouterLogs, (*scalingo.Client).AppsRouterLogs},
+	}
+	for _, setting := range settings {
+		setting := setting
+		if setting.desired == nil || (setting.live != nil && *setting.live == *setting.desired) {
+			continue
+		}
+		enable := *setting.desired
+		p.add(change{action: actionUpdate, resource: "routing", name: setting.name, details: fmt.Sprintf("%v → %v", !enable, enable), apply: func(ctx context.Context, client *scalingo.Client) error {
+			_, err := setting.apply(client, ctx, app, enable)
+			return err
+		}})
+	}
+}
+
+func (p *plan) diffNotifiers(desired Manifest, live state, prune bool) {
+	if desired.Notifiers == nil {
+		return
+	}
+	app := p.app
+	eventTypes := live.eventTypes
+	liveNotifiers := map[string]Notifier{}
+	for _, n := range live.Notifiers {
+		liveNotifiers[n.Name] = n
+	}
+
+	desiredNotifiers := map[string]bool{}
+	for _, notifier := range desired.Notifiers {
+		notifier := notifier
+		desiredNotifiers[notifier.Name] = true
+		liveNotifier, exists := liveNotifiers[notifier.Name]
+		create := change{action: actionCreate, resource: "notifiers", name: notifier.Name, details: notifier.Type, apply: func(ctx context.Context, client *scalingo.Client) error {
+			params, err := notifierParams(ctx, client, notifier, eventTypes)
+			if err != nil {
+				return err
+			}
+			_, err = client.NotifierProvision(ctx, app, params)
+			return err
+		}}
+
+		if !exists {
+			p.add(create)
+			continue
+		}
+		if notifiersEqual(notifier, liveNotifier) {
+			continue
+		}
+		id := live.notifierIDs[notifier.Name]
+		if notifier.Type != liveNotifier.Type {
+			// The type of a notifier cannot be changed, it is replaced
+			p.add(change{action: actionDelete, resource: "notifiers", name: notifier.Name, details: liveNotifier.Type, apply: func(ctx context.Context, client *scalingo.Client) error {
+				return client.NotifierDestroy(ctx, app, id)
+			}})
+			p.add(create)
+			continue
+		}
+		p.add(change{action: actionUpdate, resource: "notifiers", name: notifier.Name, apply: func(ctx context.Context, client *scalingo.Client) error {
+			params, err := notifierParams(ctx, client, notifier, eventTypes)
+			if err != nil {
+				return err
+			}
+			_, err = client.NotifierUpdate(ctx, app, id, params)
+			return err
+		}})
+	}
+
+	if !prune {
+		return
+	}
+	for _, notifier := range live.Notifiers {
+		if desiredNotifiers[notifier.Name] {
+			continue
+		}
+		id := live.notifierIDs[notifier.Name]
+		p.add(change{action: actionDelete, resource: "notifiers", name: notifier.Name, apply: func(ctx context.Context, client *scalingo.Client) error {
+			return client.NotifierDestroy(ctx, app, id)
+		}})
+	}
+}
+
+func (p *plan) diffAlerts(desired Manifest, live state, prune bool) {
+	if desired.Alerts == nil {
+		return
+	}
+	app := p.app
+	liveAlerts := matchAlerts(desired.Alerts, live.Alerts)
+
+	matched := make([]bool, len(live.Alerts))
+	for i, alert := range desired.Alerts {
+		alert := alert
+		name := alertName(alert)
+		liveIndex := liveAlerts[i]
+		if liveIndex == -1 {
+			p.add(change{action: actionCreate, resource: "alerts", name: name, details: fmt.Sprintf("limit %v", alert.Limit), apply: func(ctx context.Context, client *scalingo.Client) error {
+				params, err := alertUpdateParams(ctx, client, app, alert)
+				if err != nil {
+					return err
+				}
+				_, err = client.AlertAdd(ctx, app, scalingo.AlertAddParams{
+					ContainerType:         alert.ContainerType,
+					Metric:                alert.Metric,
+					Limit:                 alert.Limit,
+					Disabled:              alert.Disabled,
+					RemindEvery:           params.RemindEvery,
+					DurationBeforeTrigger: params.DurationBeforeTrigger,
+					SendWhenBelow:         alert.SendWhenBelow,
+					Notifiers:             *params.Notifiers,
+				})
+				return err
+			}})
+			continue
+		}
+		matched[liveIndex] = true
+		liveAlert := live.Alerts[liveIndex]
+		if alertsEqual(alert, liveAlert) {
+			continue
+		}
+		id := live.alertID(liveIndex)
+		details := fmt.Sprintf("limit %v", alert.Limit)
+		if alert.Limit != liveAlert.Limit {
+			details = fmt.Sprintf("limit %v → %v", liveAlert.Limit, alert.Limit)
+		}
+		p.add(change{action: actionUpdate, resource: "alerts", name: name, details: details, apply: func(ctx context.Context, client *scalingo.Client) error {
+			params, err := alertUpdateParams(ctx, client, app, alert)
+			if err != nil {
+				return err
+			}
+			_, err = client.AlertUpdate(ctx, app, id, params)
+			return err
+		}})
+	}
+
+	if !prune {
+		return
+	}
+	for i, alert := range live.Alerts {
+		if matched[i] {
+			continue
+		}
+		id := live.alertID(i)
+		p.add(change{action: actionDelete, resource: "alerts", name: alertName(alert), details: fmt.Sprintf("limit %v", alert.Limit), apply: func(ctx context.Context, client *scalingo.Client) error {
+			return client.AlertRemove(ctx, app, id)
+		}})
+	}
+}
+
+// matchAlerts returns, for each desired alert, the index of the live alert it
+// corresponds to, or -1 if it must be created. Several alerts may watch the
+// same metric of a container type: the identical alerts are matched first,
+// then the alerts with the same limit, then the remaining ones in order.
+func matchAlerts(desired, live []Alert) []int {
+	res := make([]int, len(desired))
+	for i := range res {
+		res[i] = -1
+	}
+	used := make([]bool, len(live))
+	passes := []func(d, l Alert) bool{
+		alertsEqual,
+		func(d, l Alert) bool { return d.key() == l.key() },
+		func(d, l Alert) bool { return d.ContainerType == l.ContainerType && d.Metric == l.Metric },
+	}
+	for _, match := range passes {
+		for i, d := range desired {
+			if res[i] != -1 {
+				continue
+			}
+			for j, l := range live {
+				if !used[j] && match(d, l) {
+					res[i] = j
+					used[j] = true
+					break
+				}
+			}
+		}
+	}
+	return res
+}
+
+func (p *plan) diffAutoscalers(desired Manifest, live state, prune bool) {
+	if desired.Autoscalers == nil {
+		return
+	}
+	app := p.app
+	liveAutoscalers := map[string]Autoscaler{}
+	for _, a := range live.Autoscalers {
+		liveAutoscalers[a.ContainerType] = a
+	}
+
+	desiredAutoscalers := map[string]bool{}
+	for _, autoscaler := range desired.Autoscalers {
+		autoscaler := autoscaler
+		desiredAutoscalers[autoscaler.ContainerType] = true
+		liveAutoscaler, exists := liveAutoscalers[autoscaler.ContainerType]
+		if exists && liveAutoscaler == autoscaler {
+			continue
+		}
+		details := fmt.Sprintf("%s %v, %d to %d containers", autoscaler.Metric, autoscaler.Target, autoscaler.MinContainers, autoscaler.MaxContainers)
+		if !exists {
+			p.add(change{action: actionCreate, resource: "autoscalers", name: autoscaler.ContainerType, details: details, apply: func(ctx context.Context, client *scalingo.Client) error {
+				a, err := client.AutoscalerAdd(ctx, app, scalingo.AutoscalerAddParams{
+					ContainerType: autoscaler.ContainerType,
+					Metric:        autoscaler.Metric,
+					Target:        autoscaler.Target,
+					MinContainers: autoscaler.MinContainers,
+					MaxContainers: autoscaler.MaxContainers,
+				})
+				if err != nil || !autoscaler.Disabled {
+					return err
+				}
+				_, err = client.AutoscalerUpdate(ctx, app, a.ID, scalingo.AutoscalerUpdateParams{Disabled: &autoscaler.Disabled})
+				return err
+			}})
+			continue
+		}
+		id := live.autoscalerIDs[autoscaler.ContainerType]
+		p.add(change{action: actionUpdate, resource: "autoscalers", name: autoscaler.ContainerType, details: details, apply: func(ctx context.Context, client *scalingo.Client) error {
+			_, err := client.AutoscalerUpdate(ctx, app, id, scalingo.AutoscalerUpdateParams{
+				Metric:        &autoscaler.Metric,
+				Target:        &autoscaler.Target,
+				MinContainers: &autoscaler.MinContainers,
+				MaxContainers: &autoscaler.MaxContainers,
+				Disabled:      &autoscaler.Disabled,
+			})
+			return err
+		}})
+	}
+
+	if !prune {
+		return
+	}
+	for _, autoscaler := range live.Autoscalers {
+		if desiredAutoscalers[autoscaler.ContainerType] {
+			continue
+		}
+		id := live.autoscalerIDs[autoscaler.ContainerType]
+		p.add(change{action: actionDelete, resource: "autoscalers", name: autoscaler.ContainerType, apply: func(ctx context.Context, client *scalingo.Client) error {
+			return client.AutoscalerRemove(ctx, app, id)
+		}})
+	}
+}
+
+func formatFormation(f Formation) string {
+	if f.Amount == 0 {
+		return "0"
+	}
+	if f.Size == "" {
+		return fmt.Sprint(f.Amount)
+	}
+	return fmt.Sprintf("%d - %s", f.Amount, f.Size)
+}
+
+func alertName(a Alert) string {
+	return a.ContainerType + ":" + a.Metric
+}
+
+func notifiersEqual(a, b Notifier) bool {
+	if a.isActive() != b.isActive() {
+		return false
+	}
+	a.Active, b.Active = nil, nil
+	return reflect.DeepEqual(normalizeNotifier(a), normalizeNotifier(b))
+}
+
+func normalizeNotifier(n Notifier) Notifier {
+	n.Events = sortedCopy(n.Events)
+	n.Emails = sortedCopy(n.Emails)
+	n.UserIDs = sortedCopy(n.UserIDs)
+	return n
+}
+
+func alertsEqual(a, b Alert) bool {
+	a.DurationBeforeTrigger = normalizeDuration(a.DurationBeforeTrigger)
+	a.RemindEvery = normalizeDuration(a.RemindEvery)
+	b.DurationBeforeTrigger = normalizeDuration(b.DurationBeforeTrigger)
+	b.RemindEvery = normalizeDuration(b.RemindEvery)
+	a.Notifiers = sortedCopy(a.Notifiers)
+	b.Notifiers = sortedCopy(b.Notifiers)
+	return reflect.DeepEqual(a, b)
+}
+
+// sortedCopy returns a sorted copy of the slice, nil if it is empty
+func sortedCopy(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	res := append([]string{}, s...)
+	sort.Strings(res)
+	return res
+}
+
+func addonPlanID(ctx context.Context, c *scalingo.Client, addon Addon) (string, error) {
+	plans, err := c.AddonProviderPlansList(ctx, addon.Provider)
+	if err != nil {
+		return "", errgo.Notef(err, "fail to list the plans of %s", addon.Provider)
+	}
+	for _, plan := range plans {
+		if plan.Name == addon.Plan {
+			return plan.ID, nil
+		}
+	}
+	return "", errgo.Newf("plan %s doesn't exist for addon %s", addon.Plan, addon.Provider)
+}
+
+func notifierParams(ctx context.Context, c *scalingo.Client, n Notifier, eventTypes []scalingo.EventType) (scalingo.NotifierParams, error) {
+	platforms, err := c.NotificationPlatformByName(ctx, n.Type)
+	if err != nil {
+		return scalingo.NotifierParams{}, errgo.Notef(err, "fail to get the notification platform %s", n.Type)
+	}
+	if len(platforms) == 0 {
+		return scalingo.NotifierParams{}, errgo.Newf("notification platform \"%s\" has not been found", n.Type)
+	}
+
+	params := scalingo.NotifierParams{
+		Active:        boolPtr(n.isActive()),
+		Name:          n.Name,
+		SendAllEvents: boolPtr(n.SendAllEvents),
+		SendAllAlerts: boolPtr(n.SendAllAlerts),
+		PlatformID:    platforms[0].ID,
+		Emails:        n.Emails,
+		UserIDs:       n.UserIDs,
+		WebhookURL:    n.WebhookURL,
+	}
+	for _, name := range n.Events {
+		found := false
+		for _, t := range eventTypes {
+			if t.Name == name {
+				params.SelectedEventIDs = append(params.SelectedEventIDs, t.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return params, errgo.Newf("unknown event type '%s'", name)
+		}
+	}
+	return params, nil
+}
+
+// alertUpdateParams builds the parameters of an alert. The notifiers are
+// resolved when the change is applied since they may have been created by a
+// previous change.
+func alertUpdateParams(ctx context.Context, c *scalingo.Client, app string, a Alert) (scalingo.AlertUpdateParams, error) {
+	params := scalingo.AlertUpdateParams{
+		ContainerType: &a.ContainerType,
+		Metric:        &a.Metric,
+		Limit:         &a.Limit,
+		Disabled:      &a.Disabled,
+		SendWhenBelow: &a.SendWhenBelow,
+	}
+	if a.DurationBeforeTrigger != "" {
+		d, err := time.ParseDuration(a.DurationBeforeTrigger)
+		if err != nil {
+			return params, errgo.Notef(err, "invalid duration_before_trigger of alert %s", alertName(a))
+		}
+		params.DurationBeforeTrigger = &d
+	}
+	if a.RemindEvery != "" {
+		d, err := time.ParseDuration(a.RemindEvery)
+		if err != nil {
+			return params, errgo.Notef(err, "invalid remind_every of alert %s", alertName(a))
+		}
+		params.RemindEvery = &d
+	}
+
+	notifierIDs := []string{}
+	if len(a.Notifiers) > 0 {
+		notifiers, err := c.NotifiersList(ctx, app)
+		if err != nil {
+			return params, errgo.Notef(err, "fail to list the notifiers")
+		}
+		for _, name := range a.Notifiers {
+			found := false
+			for _, n := range notifiers {
+				if n.GetName() == name {
+					notifierIDs = append(notifierIDs, n.GetID())
+					found = true
+					break
+				}
+			}
+			if !found {
+				return params, errgo.Newf("unknown notifier '%s' in alert %s", name, alertName(a))
+			}
+		}
+	}
+	params.Notifiers = &notifierIDs
+	return params, nil
+}
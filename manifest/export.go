@@ -0,0 +1,42 @@
+package manifest
+
+import (
+	"context"
+	"os"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+)
+
+// Export writes the manifest describing the current configuration of an app.
+// The manifest is written on stdout if path is empty or "-".
+func Export(ctx context.Context, app, path string) error {
+	c, err := config.ScalingoClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	live, err := fetchState(ctx, c, app)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the current state of %s", app)
+	}
+
+	if path == "" || path == "-" {
+		return write(os.Stdout, live.Manifest)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errgo.Notef(err, "fail to open file")
+	}
+	defer f.Close()
+
+	err = write(f, live.Manifest)
+	if err != nil {
+		return errgo.Mask(err)
+	}
+	io.Statusf("The manifest of %s has been written to %s\n", app, path)
+	return nil
+}
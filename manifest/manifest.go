@@ -0,0 +1,124 @@
+// Package manifest gathers the command handlers managing the configuration of
+// an app from a YAML manifest: `export` generates the manifest of an existing
+// app and `apply` converges an app to the state described by a manifest.
+//
+// A section missing from the manifest is not managed: nothing is changed in
+// the app for this kind of resource.
+package manifest
+
+import (
+	"bytes"
+	stdio "io"
+	"os"
+
+	"gopkg.in/errgo.v1"
+	"gopkg.in/yaml.v3"
+)
+
+type Manifest struct {
+	App         string               `yaml:"app,omitempty"`
+	Env         map[string]string    `yaml:"env,omitempty"`
+	Formation   map[string]Formation `yaml:"formation,omitempty"`
+	Addons      []Addon              `yaml:"addons,omitempty"`
+	Domains     []Domain             `yaml:"domains,omitempty"`
+	Routing     *Routing             `yaml:"routing,omitempty"`
+	Notifiers   []Notifier           `yaml:"notifiers,omitempty"`
+	Alerts      []Alert              `yaml:"alerts,omitempty"`
+	Autoscalers []Autoscaler         `yaml:"autoscalers,omitempty"`
+}
+
+type Formation struct {
+	Amount int    `yaml:"amount"`
+	Size   string `yaml:"size,omitempty"`
+}
+
+type Addon struct {
+	Provider string `yaml:"provider"`
+	Plan     string `yaml:"plan"`
+}
+
+type Domain struct {
+	Name      string `yaml:"name"`
+	Canonical bool   `yaml:"canonical,omitempty"`
+}
+
+type Routing struct {
+	ForceHTTPS    *bool `yaml:"force_https,omitempty"`
+	StickySession *bool `yaml:"sticky_session,omitempty"`
+	RouterLogs    *bool `yaml:"router_logs,omitempty"`
+}
+
+type Notifier struct {
+	Name string `yaml:"name"`
+	// Type is the name of the notification platform (e.g. email, slack, webhook)
+	Type          string `yaml:"type"`
+	Active        *bool  `yaml:"active,omitempty"`
+	SendAllEvents bool   `yaml:"send_all_events,omitempty"`
+	SendAllAlerts bool   `yaml:"send_all_alerts,omitempty"`
+	// Events are the names of the selected event types
+	Events     []string `yaml:"events,omitempty"`
+	Emails     []string `yaml:"emails,omitempty"`
+	UserIDs    []string `yaml:"user_ids,omitempty"`
+	WebhookURL string   `yaml:"webhook_url,omitempty"`
+}
+
+// Alert is identified by its container type, its metric, its limit and
+// whether it is sent when the metric is below the limit
+type Alert struct {
+	ContainerType         string  `yaml:"container_type"`
+	Metric                string  `yaml:"metric"`
+	Limit                 float64 `yaml:"limit"`
+	SendWhenBelow         bool    `yaml:"send_when_below,omitempty"`
+	DurationBeforeTrigger string  `yaml:"duration_before_trigger,omitempty"`
+	RemindEvery           string  `yaml:"remind_every,omitempty"`
+	Disabled              bool    `yaml:"disabled,omitempty"`
+	// Notifiers are the names of the notifiers of the app
+	Notifiers []string `yaml:"notifiers,omitempty"`
+}
+
+// Autoscaler is identified by its container type
+type Autoscaler struct {
+	ContainerType string  `yaml:"container_type"`
+	Metric        string  `yaml:"metric"`
+	Target        float64 `yaml:"target"`
+	MinContainers int     `yaml:"min_containers"`
+	MaxContainers int     `yaml:"max_containers"`
+	Disabled      bool    `yaml:"disabled,omitempty"`
+}
+
+func (n Notifier) isActive() bool {
+	return n.Active == nil || *n.Active
+}
+
+// Load reads a manifest, "-" reads it from stdin
+func Load(path string) (Manifest, error) {
+	var content []byte
+	var err error
+	if path == "-" {
+		content, err = stdio.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return Manifest{}, errgo.Notef(err, "fail to read the manifest")
+	}
+
+	var m Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&m)
+	if err != nil && err != stdio.EOF {
+		return Manifest{}, errgo.Notef(err, "invalid manifest %s", path)
+	}
+	return m, nil
+}
+
+func write(w stdio.Writer, m Manifest) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	err := encoder.Encode(m)
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the manifest")
+	}
+	return encoder.Close()
+}
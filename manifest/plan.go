@@ -0,0 +1,105 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+type action string
+
+const (
+	actionCreate action = "create"
+	actionUpdate action = "update"
+	actionDelete action = "delete"
+)
+
+type change struct {
+	action   action
+	resource string
+	name     string
+	details  string
+	// apply executes the change. It is nil for the formation changes and for
+	// the variables to set: they are all applied at once with a single scaling
+	// operation and a single request setting the variables.
+	apply func(ctx context.Context, c *scalingo.Client) error
+	// scale is the new configuration of a container type
+	scale *scalingo.ContainerType
+	// variable is the environment variable to set
+	variable *scalingo.Variable
+}
+
+type plan struct {
+	app     string
+	changes []change
+	// warnings are displayed along with the plan, for instance for the
+	// resources which are not in the manifest but cannot be deleted
+	warnings []string
+}
+
+// newPlan computes the changes to do on the live state to converge to the
+// desired manifest. If prune is true, the resources missing from a section of
+// the manifest are deleted.
+func newPlan(desired Manifest, live state, prune bool) plan {
+	p := &plan{app: live.App}
+	p.diffEnv(desired, live, prune)
+	p.diffAddons(desired, live)
+	p.diffFormation(desired, live, prune)
+	p.diffDomains(desired, live, prune)
+	p.diffRouting(desired, live)
+	// Notifiers must be created before the alerts referencing them
+	p.diffNotifiers(desired, live, prune)
+	p.diffAlerts(desired, live, prune)
+	p.diffAutoscalers(desired, live, prune)
+	return *p
+}
+
+func (p *plan) add(c change) {
+	p.changes = append(p.changes, c)
+}
+
+func (p plan) isEmpty() bool {
+	return len(p.changes) == 0
+}
+
+func (p plan) display() {
+	for _, warning := range p.warnings {
+		io.Warning(warning)
+	}
+
+	if p.isEmpty() {
+		io.Statusf("No changes, %s is up to date with the manifest\n", p.app)
+		return
+	}
+
+	fmt.Printf("The following changes will be applied on %s:\n\n", p.app)
+	counts := map[action]int{}
+	for _, c := range p.changes {
+		counts[c.action]++
+		line := fmt.Sprintf("%s.%s", c.resource, c.name)
+		if c.details != "" {
+			line += ": " + c.details
+		}
+		switch c.action {
+		case actionCreate:
+			fmt.Println(io.Green("  + " + line))
+		case actionUpdate:
+			fmt.Println(io.Yellow("  ~ " + line))
+		case actionDelete:
+			fmt.Println(io.BoldRed("  - " + line))
+		}
+	}
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete.\n", counts[actionCreate], counts[actionUpdate], counts[actionDelete])
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
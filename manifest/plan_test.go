@@ -0,0 +1,124 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testChange struct {
+	action   action
+	resource string
+	name     string
+	details  string
+}
+
+func TestNewPlan(t *testing.T) {
+	live := state{
+		Manifest: Manifest{
+			App:       "my-app",
+			Env:       map[string]string{"FOO": "foo", "BAR": "bar", "UNCHANGED": "value"},
+			Formation: map[string]Formation{"web": {Amount: 2, Size: "M"}, "worker": {Amount: 1, Size: "M"}},
+			Addons:    []Addon{{Provider: "postgresql", Plan: "postgresql-starter-512"}, {Provider: "redis", Plan: "redis-starter-256"}},
+			Domains:   []Domain{{Name: "old.example.com", Canonical: true}},
+			Routing:   &Routing{ForceHTTPS: boolPtr(false), StickySession: boolPtr(false), RouterLogs: boolPtr(false)},
+			Alerts: []Alert{
+				{ContainerType: "web", Metric: "cpu", Limit: 0.8, DurationBeforeTrigger: "5m0s"},
+				{ContainerType: "web", Metric: "cpu", Limit: 0.95},
+			},
+		},
+		alertIDs: []string{"alert-cpu-80", "alert-cpu-95"},
+	}
+
+	tests := map[string]struct {
+		desired          Manifest
+		prune            bool
+		expectedChanges  []testChange
+		expectedWarnings []string
+	}{
+		"it should not change anything if the manifest is empty": {
+			desired: Manifest{},
+			prune:   true,
+		},
+		"it should create and update the environment variables": {
+			desired: Manifest{Env: map[string]string{"FOO": "new", "NEW": "value", "UNCHANGED": "value"}},
+			expectedChanges: []testChange{
+				{actionUpdate, "env", "FOO", "value changed"},
+				{actionCreate, "env", "NEW", ""},
+			},
+		},
+		"it should delete the environment variables missing from the manifest with prune": {
+			desired: Manifest{Env: map[string]string{"FOO": "foo"}},
+			prune:   true,
+			expectedChanges: []testChange{
+				{actionDelete, "env", "BAR", ""},
+				{actionDelete, "env", "UNCHANGED", ""},
+			},
+		},
+		"it should scale the container types": {
+			desired: Manifest{Formation: map[string]Formation{"web": {Amount: 3}, "clock": {Amount: 1, Size: "S"}}},
+			prune:   true,
+			expectedChanges: []testChange{
+				{actionCreate, "formation", "clock", "1 - S"},
+				{actionUpdate, "formation", "web", "2 - M → 3"},
+				{actionUpdate, "formation", "worker", "1 - M → 0"},
+			},
+		},
+		"it should never delete an addon": {
+			desired: Manifest{Addons: []Addon{{Provider: "postgresql", Plan: "postgresql-business-1024"}}},
+			prune:   true,
+			expectedChanges: []testChange{
+				{actionUpdate, "addons", "postgresql", "postgresql-starter-512 → postgresql-business-1024"},
+			},
+			expectedWarnings: []string{"The redis addon is not in the manifest, addons are never removed by apply, use 'addons-remove' to remove it"},
+		},
+		"it should change the canonical domain": {
+			desired: Manifest{Domains: []Domain{{Name: "old.example.com"}, {Name: "new.example.com", Canonical: true}}},
+			expectedChanges: []testChange{
+				{actionCreate, "domains", "new.example.com", "canonical"},
+			},
+		},
+		"it should only change the routing settings given": {
+			desired: Manifest{Routing: &Routing{ForceHTTPS: boolPtr(true)}},
+			expectedChanges: []testChange{
+				{actionUpdate, "routing", "force_https", "false → true"},
+			},
+		},
+		"it should compare the durations of the alerts whatever their format": {
+			desired: Manifest{Alerts: []Alert{
+				{ContainerType: "web", Metric: "cpu", Limit: 0.95},
+				{ContainerType: "web", Metric: "cpu", Limit: 0.8, DurationBeforeTrigger: "300s"},
+			}},
+		},
+		"it should update the alert with the same limit among the alerts of a metric": {
+			desired: Manifest{Alerts: []Alert{
+				{ContainerType: "web", Metric: "cpu", Limit: 0.8, DurationBeforeTrigger: "5m0s"},
+				{ContainerType: "web", Metric: "cpu", Limit: 0.95, RemindEvery: "1h"},
+			}},
+			expectedChanges: []testChange{
+				{actionUpdate, "alerts", "web:cpu", "limit 0.95"},
+			},
+		},
+		"it should only delete the alert missing from the manifest with prune": {
+			desired: Manifest{Alerts: []Alert{{ContainerType: "web", Metric: "cpu", Limit: 0.9}}},
+			prune:   true,
+			expectedChanges: []testChange{
+				{actionUpdate, "alerts", "web:cpu", "limit 0.8 → 0.9"},
+				{actionDelete, "alerts", "web:cpu", "limit 0.95"},
+			},
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			p := newPlan(test.desired, live, test.prune)
+
+			var changes []testChange
+			for _, c := range p.changes {
+				changes = append(changes, testChange{c.action, c.resource, c.name, c.details})
+			}
+			assert.Equal(t, test.expectedChanges, changes)
+			assert.Equal(t, test.expectedWarnings, p.warnings)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package manifest
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+// state is the live configuration of an app, along with the IDs of its
+// resources needed to update them
+type state struct {
+	Manifest
+
+	variableIDs map[string]string
+	addonIDs    map[string]string
+	domainIDs   map[string]string
+	notifierIDs map[string]string
+	// alertIDs are the IDs of the alerts, in the order of Alerts: several
+	// alerts may watch the same metric of a container type
+	alertIDs      []string
+	autoscalerIDs map[string]string
+	eventTypes    []scalingo.EventType
+}
+
+type alertKey struct {
+	containerType string
+	metric        string
+	limit         float64
+	sendWhenBelow bool
+}
+
+// key identifies an alert: the same metric of a container type may be
+// watched by several alerts with different limits
+func (a Alert) key() alertKey {
+	return alertKey{containerType: a.ContainerType, metric: a.Metric, limit: a.Limit, sendWhenBelow: a.SendWhenBelow}
+}
+
+func (s state) alertID(i int) string {
+	if i >= len(s.alertIDs) {
+		return ""
+	}
+	return s.alertIDs[i]
+}
+
+// isAddonVariable returns true if the variable is defined by one of the
+// addons, e.g. SCALINGO_POSTGRESQL_URL for the postgresql addon or
+// SCALINGO_MONGO_URL for the mongodb addon. These variables are managed by
+// the addons, they are neither exported nor pruned.
+func isAddonVariable(name string, addons []Addon) bool {
+	for _, addon := range addons {
+		provider := strings.ToUpper(strings.ReplaceAll(addon.Provider, "-", "_"))
+		for _, prefix := range []string{provider, strings.TrimSuffix(provider, "DB")} {
+			if strings.HasPrefix(name, "SCALINGO_"+prefix+"_") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fetchState(ctx context.Context, c *scalingo.Client, app string) (state, error) {
+	s := state{
+		Manifest:      Manifest{App: app},
+		variableIDs:   map[string]string{},
+		addonIDs:      map[string]string{},
+		domainIDs:     map[string]string{},
+		notifierIDs:   map[string]string{},
+		autoscalerIDs: map[string]string{},
+	}
+
+	addons, err := c.AddonsList(ctx, app)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to list the addons")
+	}
+	s.Addons = []Addon{}
+	for _, addon := range addons {
+		if addon.AddonProvider == nil || addon.Plan == nil {
+			continue
+		}
+		s.Addons = append(s.Addons, Addon{Provider: addon.AddonProvider.ID, Plan: addon.Plan.Name})
+		s.addonIDs[addon.AddonProvider.ID] = addon.ID
+	}
+
+	// The aliases are kept as references (e.g. DATABASE_URL=$SCALINGO_POSTGRESQL_URL)
+	// rather than resolved to the values of the variables they reference
+	variables, err := c.VariablesListWithoutAlias(ctx, app)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to list the environment variables")
+	}
+	s.Env = map[string]string{}
+	for _, v := range variables {
+		if isAddonVariable(v.Name, s.Addons) {
+			continue
+		}
+		s.Env[v.Name] = v.Value
+		s.variableIDs[v.Name] = v.ID
+	}
+
+	containerTypes, err := c.AppsContainerTypes(ctx, app)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to list the container types")
+	}
+	s.Formation = map[string]Formation{}
+	for _, ct := range containerTypes {
+		s.Formation[ct.Name] = Formation{Amount: ct.Amount, Size: ct.Size}
+	}
+
+	domains, err := c.DomainsList(ctx, app)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to list the domains")
+	}
+	s.Domains = []Domain{}
+	for _, domain := range domains {
+		s.Domains = append(s.Domains, Domain{Name: domain.Name, Canonical: domain.Canonical})
+		s.domainIDs[domain.Name] = domain.ID
+	}
+
+	scalingoApp, err := c.AppsShow(ctx, app)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to get the app")
+	}
+	s.Routing = &Routing{
+		ForceHTTPS:    boolPtr(scalingoApp.ForceHTTPS),
+		StickySession: boolPtr(scalingoApp.StickySession),
+		RouterLogs:    boolPtr(scalingoApp.RouterLogs),
+	}
+
+	s.eventTypes, err = c.EventTypesList(ctx)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to list the event types")
+	}
+
+	notifiers, err := c.NotifiersList(ctx, app)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to list the notifiers")
+	}
+	notifierNames := map[string]string{}
+	s.Notifiers = []Notifier{}
+	for _, n := range notifiers {
+		s.Notifiers = append(s.Notifiers, newNotifier(n, s.eventTypes))
+		s.notifierIDs[n.GetName()] = n.GetID()
+		notifierNames[n.GetID()] = n.GetName()
+	}
+
+	alerts, err := c.AlertsList(ctx, app)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to list the alerts")
+	}
+	s.Alerts = []Alert{}
+	for _, a := range alerts {
+		alert := Alert{
+			ContainerType:         a.ContainerType,
+			Metric:                a.Metric,
+			Limit:                 a.Limit,
+			SendWhenBelow:         a.SendWhenBelow,
+			DurationBeforeTrigger: formatDuration(a.DurationBeforeTrigger),
+			RemindEvery:           normalizeDuration(a.RemindEvery),
+			Disabled:              a.Disabled,
+		}
+		for _, id := range a.Notifiers {
+			alert.Notifiers = append(alert.Notifiers, notifierNames[id])
+		}
+		sort.Strings(alert.Notifiers)
+		s.Alerts = append(s.Alerts, alert)
+		s.alertIDs = append(s.alertIDs, a.ID)
+	}
+
+	autoscalers, err := c.AutoscalersList(ctx, app)
+	if err != nil {
+		return s, errgo.Notef(err, "fail to list the autoscalers")
+	}
+	s.Autoscalers = []Autoscaler{}
+	for _, a := range autoscalers {
+		s.Autoscalers = append(s.Autoscalers, Autoscaler{
+			ContainerType: a.ContainerType,
+			Metric:        a.Metric,
+			Target:        a.Target,
+			MinContainers: a.MinContainers,
+			MaxContainers: a.MaxContainers,
+			Disabled:      a.Disabled,
+		})
+		s.autoscalerIDs[a.ContainerType] = a.ID
+	}
+
+	return s, nil
+}
+
+func newNotifier(n scalingo.DetailedNotifier, eventTypes []scalingo.EventType) Notifier {
+	notifier := Notifier{
+		Name:          n.GetName(),
+		Type:          string(n.GetType()),
+		Active:        boolPtr(n.IsActive()),
+		SendAllEvents: n.GetSendAllEvents(),
+		SendAllAlerts: n.GetSendAllAlerts(),
+	}
+	for _, id := range n.GetSelectedEventIDs() {
+		for _, t := range eventTypes {
+			if t.ID == id {
+				notifier.Events = append(notifier.Events, t.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(notifier.Events)
+
+	switch typed := n.(type) {
+	case *scalingo.NotifierWebhookType:
+		notifier.WebhookURL = typed.TypeData.WebhookURL
+	case *scalingo.NotifierSlackType:
+		notifier.WebhookURL = typed.TypeData.WebhookURL
+	case *scalingo.NotifierEmailType:
+		notifier.Emails = typed.TypeData.Emails
+		notifier.UserIDs = typed.TypeData.UserIDs
+	}
+	return notifier
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// normalizeDuration formats a duration the same way whatever the way it has
+// been written, i.e. "60m" and "1h" are both "1h0m0s"
+func normalizeDuration(duration string) string {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return duration
+	}
+	return formatDuration(d)
+}
@@ -0,0 +1,25 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAddonVariable(t *testing.T) {
+	addons := []Addon{{Provider: "postgresql"}, {Provider: "mongodb"}}
+
+	tests := map[string]bool{
+		"SCALINGO_POSTGRESQL_URL":      true,
+		"SCALINGO_POSTGRESQL_URL_READ": true,
+		"SCALINGO_MONGO_URL":           true,
+		"SCALINGO_REDIS_URL":           false,
+		"DATABASE_URL":                 false,
+		"SCALINGO_POSTGRESQL":          false,
+	}
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, isAddonVariable(name, addons))
+		})
+	}
+}
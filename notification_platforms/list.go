@@ -2,12 +2,11 @@ package notification_platforms
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context) error {
@@ -21,13 +20,11 @@ func List(ctx context.Context) error {
 		return errgo.Mask(err, errgo.Any)
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(resources)
 	t.SetHeader([]string{"Name"})
 
 	for _, r := range resources {
 		t.Append([]string{r.Name})
 	}
-	t.Render()
-
-	return nil
+	return t.Render()
 }
@@ -3,14 +3,13 @@ package notifiers
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -30,12 +29,11 @@ func Details(ctx context.Context, app, ID string) error {
 		return errgo.Notef(err, "fail to list event types")
 	}
 
-	displayDetails(notifier, eventTypes)
-	return nil
+	return displayDetails(notifier, eventTypes)
 }
 
-func displayDetails(notifier scalingo.DetailedNotifier, types []scalingo.EventType) {
-	t := tablewriter.NewWriter(os.Stdout)
+func displayDetails(notifier scalingo.DetailedNotifier, types []scalingo.EventType) error {
+	t := output.NewTable(notifier)
 	// Basic data
 	data := [][]string{
 		[]string{"ID", notifier.GetID()},
@@ -74,5 +72,5 @@ func displayDetails(notifier scalingo.DetailedNotifier, types []scalingo.EventTy
 			}
 		}
 	}
-	t.Render()
+	return t.Render()
 }
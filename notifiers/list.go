@@ -3,13 +3,12 @@ package notifiers
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	scalingo "github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -23,7 +22,7 @@ func List(ctx context.Context, app string) error {
 		return errgo.Mask(err, errgo.Any)
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(notifiers)
 	t.SetHeader([]string{"ID", "Type", "Name", "Enabled", "Send all events", "Selected events"})
 
 	eventTypes, err := c.EventTypesList(ctx)
@@ -42,9 +41,7 @@ func List(ctx context.Context, app string) error {
 			selectedEvents,
 		})
 	}
-	t.Render()
-
-	return nil
+	return t.Render()
 }
 
 func eventTypesToString(eventTypes []scalingo.EventType, ids []string) string {
@@ -0,0 +1,31 @@
+package output
+
+import (
+	"context"
+	stdio "io"
+)
+
+type writerKey struct{}
+
+// WithWriter returns a context in which the commands display their output in w
+// instead of the standard output. It is used to capture the output of a
+// command run on several apps at the same time.
+func WithWriter(ctx context.Context, w stdio.Writer) context.Context {
+	return context.WithValue(ctx, writerKey{}, w)
+}
+
+// Writer returns the writer set in the context with WithWriter, or the
+// standard output
+func Writer(ctx context.Context) stdio.Writer {
+	if w, ok := ctx.Value(writerKey{}).(stdio.Writer); ok {
+		return w
+	}
+	return writer
+}
+
+// IsCaptured returns true if the output of the context is captured with
+// WithWriter. Interactive displays like spinners must then be skipped.
+func IsCaptured(ctx context.Context) bool {
+	_, ok := ctx.Value(writerKey{}).(stdio.Writer)
+	return ok
+}
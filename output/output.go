@@ -0,0 +1,134 @@
+// Package output renders the results of the listing and information commands.
+// Depending on the global flags, the data fetched from the API is displayed as
+// a table (default), as JSON or YAML documents, or through a Go template.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"os"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+)
+
+// Options are the display options shared by all the commands. They are set
+// once from the global flags before any command is run.
+type Options struct {
+	Format Format
+	// Template is a Go template executed against each displayed item
+	Template string
+	// Columns restricts the table to the given columns, identified by their
+	// header name (case insensitive, spaces can be replaced by underscores)
+	Columns []string
+}
+
+var (
+	Opts = Options{Format: FormatTable}
+
+	writer stdio.Writer = os.Stdout
+)
+
+// Configure validates the output flags and sets the global options accordingly
+func Configure(format string, template string, columns string) error {
+	opts := Options{Format: FormatTable}
+
+	switch Format(strings.ToLower(format)) {
+	case "", FormatTable:
+	case FormatJSON:
+		opts.Format = FormatJSON
+	case FormatYAML:
+		opts.Format = FormatYAML
+	default:
+		return errgo.Newf("unknown output format '%s', accepted values are: table, json, yaml", format)
+	}
+
+	if template != "" {
+		if opts.Format != FormatTable {
+			return errgo.New("--format cannot be used with a JSON or YAML output")
+		}
+		opts.Template = unescapeTemplate(template)
+	}
+
+	if columns != "" {
+		if opts.Format != FormatTable || opts.Template != "" {
+			return errgo.New("--columns can only be used with a table output")
+		}
+		for _, column := range strings.Split(columns, ",") {
+			column = strings.TrimSpace(column)
+			if column != "" {
+				opts.Columns = append(opts.Columns, column)
+			}
+		}
+	}
+
+	Opts = opts
+	return nil
+}
+
+// IsHuman returns true if the output is meant to be read by a human, i.e.
+// the commands are free to display additional messages around their data
+func IsHuman() bool {
+	return Opts.Format == FormatTable && Opts.Template == ""
+}
+
+// Data renders the given data in the requested machine-readable format. It
+// returns false if the output is human-readable: in this case the caller is in
+// charge of the display.
+func Data(data interface{}) (bool, error) {
+	if IsHuman() {
+		return false, nil
+	}
+	return true, render(writer, data)
+}
+
+func render(w stdio.Writer, data interface{}) error {
+	switch Opts.Format {
+	case FormatJSON:
+		return renderJSON(w, data)
+	case FormatYAML:
+		return renderYAML(w, data)
+	}
+	return renderTemplate(w, data)
+}
+
+func renderJSON(w stdio.Writer, data interface{}) error {
+	buffer, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the output to JSON")
+	}
+	fmt.Fprintln(w, string(buffer))
+	return nil
+}
+
+func renderYAML(w stdio.Writer, data interface{}) error {
+	// The go-scalingo structures only define JSON tags. Going through JSON
+	// ensures the YAML keys are the same as the JSON ones.
+	buffer, err := json.Marshal(data)
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the output")
+	}
+	var document interface{}
+	err = json.Unmarshal(buffer, &document)
+	if err != nil {
+		return errgo.Notef(err, "fail to decode the output")
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	err = encoder.Encode(document)
+	if err != nil {
+		return errgo.Notef(err, "fail to encode the output to YAML")
+	}
+	return encoder.Close()
+}
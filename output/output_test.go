@@ -0,0 +1,147 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func TestConfigure(t *testing.T) {
+	tests := map[string]struct {
+		format         string
+		expectedFormat Format
+		expectedErr    string
+	}{
+		"it should default to the table format": {
+			format:         "",
+			expectedFormat: FormatTable,
+		},
+		"it should accept the JSON format whatever the case": {
+			format:         "JSON",
+			expectedFormat: FormatJSON,
+		},
+		"it should accept the YAML format": {
+			format:         "yaml",
+			expectedFormat: FormatYAML,
+		},
+		"it should reject an unknown format": {
+			format:      "xml",
+			expectedErr: "unknown output format 'xml'",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			defer func() { Opts = Options{Format: FormatTable} }()
+
+			err := Configure(test.format, "", "")
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFormat, Opts.Format)
+		})
+	}
+}
+
+func TestTable_Render(t *testing.T) {
+	items := []testItem{{Name: "my-app", Status: "running"}}
+
+	tests := map[string]struct {
+		format   Format
+		expected string
+	}{
+		"it should render the data as JSON": {
+			format:   FormatJSON,
+			expected: "[\n  {\n    \"name\": \"my-app\",\n    \"status\": \"running\"\n  }\n]\n",
+		},
+		"it should render the data as YAML with the JSON keys": {
+			format:   FormatYAML,
+			expected: "- name: my-app\n  status: running\n",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			writer = buffer
+			Opts = Options{Format: test.format}
+			defer func() { Opts = Options{Format: FormatTable} }()
+
+			table := NewTable(items)
+			table.SetHeader([]string{"Name", "Status"})
+			table.Append([]string{"my-app", "running"})
+			require.NoError(t, table.Render())
+
+			assert.Equal(t, test.expected, buffer.String())
+		})
+	}
+}
+
+func TestTable_Render_Template(t *testing.T) {
+	items := []testItem{{Name: "my-app", Status: "running"}, {Name: "my-other-app", Status: "stopped"}}
+
+	buffer := new(bytes.Buffer)
+	writer = buffer
+	require.NoError(t, Configure("", `{{.Name}}\t{{upper .Status}}`, ""))
+	defer func() { Opts = Options{Format: FormatTable} }()
+
+	table := NewTable(items)
+	table.SetHeader([]string{"Name", "Status"})
+	require.NoError(t, table.Render())
+
+	assert.Equal(t, "my-app\tRUNNING\nmy-other-app\tSTOPPED\n", buffer.String())
+}
+
+func TestTable_SelectColumns(t *testing.T) {
+	tests := map[string]struct {
+		columns        string
+		expectedHeader []string
+		expectedRows   [][]string
+		expectedErr    string
+	}{
+		"it should keep all the columns by default": {
+			expectedHeader: []string{"Name", "Status", "Created At"},
+			expectedRows:   [][]string{{"my-app", "running", "2026/10/18"}},
+		},
+		"it should select and reorder the columns": {
+			columns:        "created_at, NAME",
+			expectedHeader: []string{"Created At", "Name"},
+			expectedRows:   [][]string{{"2026/10/18", "my-app"}},
+		},
+		"it should reject an unknown column": {
+			columns:     "name,size",
+			expectedErr: "unknown column 'size', available columns are: name, status, created_at",
+		},
+	}
+
+	for msg, test := range tests {
+		t.Run(msg, func(t *testing.T) {
+			require.NoError(t, Configure("", "", test.columns))
+			defer func() { Opts = Options{Format: FormatTable} }()
+
+			table := NewTable(nil)
+			table.SetHeader([]string{"Name", "Status", "Created At"})
+			table.Append([]string{"my-app", "running", "2026/10/18"})
+
+			header, rows, err := table.selectColumns()
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, test.expectedErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedHeader, header)
+			assert.Equal(t, test.expectedRows, rows)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package output
+
+import (
+	stdio "io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/errgo.v1"
+)
+
+// Table is a drop-in replacement of a tablewriter table which also keeps the
+// data it has been built from. The data is rendered instead of the table if a
+// machine-readable output has been requested.
+type Table struct {
+	data           interface{}
+	header         []string
+	rows           [][]string
+	colWidth       int
+	autoMergeCells bool
+	rowLine        bool
+	writer         stdio.Writer
+}
+
+// NewTable creates a table displaying the given data. data is usually the
+// slice of go-scalingo structures returned by the API.
+func NewTable(data interface{}) *Table {
+	return &Table{data: data}
+}
+
+func (t *Table) SetHeader(header []string) {
+	t.header = header
+}
+
+func (t *Table) SetColWidth(width int) {
+	t.colWidth = width
+}
+
+func (t *Table) SetAutoMergeCells(autoMergeCells bool) {
+	t.autoMergeCells = autoMergeCells
+}
+
+func (t *Table) SetRowLine(rowLine bool) {
+	t.rowLine = rowLine
+}
+
+// SetWriter sets the writer in which the table is rendered instead of the
+// standard output
+func (t *Table) SetWriter(w stdio.Writer) {
+	t.writer = w
+}
+
+func (t *Table) Append(row []string) {
+	t.rows = append(t.rows, row)
+}
+
+func (t *Table) Render() error {
+	w := t.writer
+	if w == nil {
+		w = writer
+	}
+	if !IsHuman() {
+		return render(w, t.data)
+	}
+
+	header, rows, err := t.selectColumns()
+	if err != nil {
+		return errgo.Mask(err)
+	}
+
+	table := tablewriter.NewWriter(w)
+	if t.colWidth != 0 {
+		table.SetColWidth(t.colWidth)
+	}
+	table.SetAutoMergeCells(t.autoMergeCells)
+	table.SetRowLine(t.rowLine)
+	if len(header) != 0 {
+		table.SetHeader(header)
+	}
+	table.AppendBulk(rows)
+	table.Render()
+	return nil
+}
+
+// selectColumns returns the header and the rows restricted to the columns
+// given with the --columns flag, in the requested order
+func (t *Table) selectColumns() ([]string, [][]string, error) {
+	if len(Opts.Columns) == 0 {
+		return t.header, t.rows, nil
+	}
+	if len(t.header) == 0 {
+		return nil, nil, errgo.New("--columns is not supported by this command")
+	}
+
+	indexes := make([]int, 0, len(Opts.Columns))
+	for _, column := range Opts.Columns {
+		index := -1
+		for i, h := range t.header {
+			if columnKey(h) == columnKey(column) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			available := make([]string, 0, len(t.header))
+			for _, h := range t.header {
+				available = append(available, columnKey(h))
+			}
+			return nil, nil, errgo.Newf("unknown column '%s', available columns are: %s", column, strings.Join(available, ", "))
+		}
+		indexes = append(indexes, index)
+	}
+
+	header := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		header = append(header, t.header[i])
+	}
+	rows := make([][]string, 0, len(t.rows))
+	for _, row := range t.rows {
+		selected := make([]string, 0, len(indexes))
+		for _, i := range indexes {
+			if i < len(row) {
+				selected = append(selected, row[i])
+			} else {
+				selected = append(selected, "")
+			}
+		}
+		rows = append(rows, selected)
+	}
+	return header, rows, nil
+}
+
+// columnKey normalizes a column name: "Created At", "created-at" and
+// "created_at" designate the same column
+func columnKey(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.Trim(strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name), "_")
+}
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	stdio "io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"gopkg.in/errgo.v1"
+)
+
+var (
+	templateFuncs = template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			buffer, err := json.Marshal(v)
+			return string(buffer), err
+		},
+		"join":  strings.Join,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+)
+
+// renderTemplate executes the template against each element of data if it is
+// a slice, or against data itself otherwise. Each execution is followed by a
+// new line.
+func renderTemplate(w stdio.Writer, data interface{}) error {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(Opts.Template)
+	if err != nil {
+		return errgo.Notef(err, "invalid --format template")
+	}
+
+	items := []interface{}{data}
+	value := reflect.Indirect(reflect.ValueOf(data))
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		items = make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, value.Index(i).Interface())
+		}
+	}
+
+	for _, item := range items {
+		err := tmpl.Execute(w, item)
+		if err != nil {
+			return errgo.Notef(err, "fail to execute the --format template")
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// unescapeTemplate allows the user to write '\t' or '\n' in a template given
+// on the command line, like in `--format '{{.Name}}\t{{.Status}}'`
+func unescapeTemplate(template string) string {
+	return strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(template)
+}
@@ -0,0 +1,179 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/signals"
+)
+
+const (
+	// ExecutablePrefix is the prefix of the executables run as 'scalingo <name>'
+	ExecutablePrefix = "scalingo-"
+	// credentialHelperPrefix is the prefix of the credential helpers, which
+	// are not plugins
+	credentialHelperPrefix = ExecutablePrefix + "credential-"
+)
+
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+type RunOpts struct {
+	App string
+}
+
+// Discover returns the plugins found in the directories of pathList, sorted
+// by name. When several executables have the same name, the first one in
+// pathList wins, like in a shell.
+func Discover(pathList string) []Plugin {
+	found := map[string]Plugin{}
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			dir = "."
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok {
+				continue
+			}
+			if _, ok := found[name]; ok {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			found[name] = Plugin{Name: name, Path: path}
+		}
+	}
+
+	plugins := make([]Plugin, 0, len(found))
+	for _, plugin := range found {
+		plugins = append(plugins, plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// Find returns the plugin named name in the PATH
+func Find(name string) (Plugin, bool) {
+	for _, plugin := range Discover(os.Getenv("PATH")) {
+		if plugin.Name == name {
+			return plugin, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// List displays the plugins found in the PATH
+func List() error {
+	plugins := Discover(os.Getenv("PATH"))
+
+	t := output.NewTable(plugins)
+	t.SetHeader([]string{"Name", "Path"})
+	for _, plugin := range plugins {
+		t.Append([]string{plugin.Name, plugin.Path})
+	}
+	return t.Render()
+}
+
+// Run executes the plugin with args. The plugin gets the current app, region,
+// API token and endpoints in its environment, so that it can use the
+// Scalingo API or run the CLI without any configuration.
+func Run(ctx context.Context, plugin Plugin, args []string, opts RunOpts) error {
+	env, err := pluginEnv(ctx, opts)
+	if err != nil {
+		return errgo.Notef(err, "fail to build the environment of the plugin")
+	}
+
+	cmd := exec.CommandContext(ctx, plugin.Path, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// The plugin handles the signals itself
+	signals.CatchQuitSignals = false
+	return cmd.Run()
+}
+
+func pluginEnv(ctx context.Context, opts RunOpts) ([]string, error) {
+	authenticator := &config.CliAuthenticator{}
+	_, token, err := authenticator.LoadAuth()
+	if err != nil {
+		return nil, errgo.Notef(err, "fail to load credentials")
+	}
+
+	apiURL, dbURL := config.C.ScalingoApiUrl, config.C.ScalingoDbUrl
+	if apiURL == "" || dbURL == "" {
+		region, err := config.GetRegion(ctx, config.C, config.C.ScalingoRegion, config.GetRegionOpts{
+			Token: token.Token,
+		})
+		if err != nil {
+			return nil, errgo.Notef(err, "fail to get region '%v' specifications", config.C.ScalingoRegion)
+		}
+		apiURL, dbURL = region.API, region.DatabaseAPI
+	}
+
+	env := []string{
+		"SCALINGO_REGION=" + config.C.ScalingoRegion,
+		"SCALINGO_API_TOKEN=" + token.Token,
+		"SCALINGO_API_URL=" + apiURL,
+		"SCALINGO_DB_URL=" + dbURL,
+		"SCALINGO_AUTH_URL=" + config.C.ScalingoAuthUrl,
+	}
+	if opts.App != "" {
+		env = append(env, "SCALINGO_APP="+opts.App)
+	}
+	if executable, err := os.Executable(); err == nil {
+		env = append(env, "SCALINGO_CLI="+executable)
+	}
+	return env, nil
+}
+
+// pluginName returns the name of the plugin of the executable named filename
+func pluginName(filename string) (string, bool) {
+	if !strings.HasPrefix(filename, ExecutablePrefix) || strings.HasPrefix(filename, credentialHelperPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(filename, ExecutablePrefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0111 != 0
+}
@@ -0,0 +1,77 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are detected with their extension on Windows")
+	}
+
+	first := t.TempDir()
+	second := t.TempDir()
+	writeFile := func(t *testing.T, dir, name string, mode os.FileMode) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode))
+	}
+	writeFile(t, first, "scalingo-hello", 0755)
+	writeFile(t, first, "scalingo-not-executable", 0644)
+	writeFile(t, first, "scalingo-credential-pass", 0755)
+	writeFile(t, first, "other-tool", 0755)
+	writeFile(t, second, "scalingo-hello", 0755)
+	writeFile(t, second, "scalingo-backup", 0755)
+	require.NoError(t, os.Mkdir(filepath.Join(second, "scalingo-dir"), 0755))
+
+	plugins := Discover(first + string(os.PathListSeparator) + second + string(os.PathListSeparator) + "/does/not/exist")
+
+	assert.Equal(t, []Plugin{
+		{Name: "backup", Path: filepath.Join(second, "scalingo-backup")},
+		{Name: "hello", Path: filepath.Join(first, "scalingo-hello")},
+	}, plugins)
+}
+
+func TestPluginName(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are detected with their extension on Windows")
+	}
+
+	tests := map[string]struct {
+		filename     string
+		expectedName string
+		expectedOK   bool
+	}{
+		"a plugin": {
+			filename:     "scalingo-hello",
+			expectedName: "hello",
+			expectedOK:   true,
+		},
+		"a plugin with dashes": {
+			filename:     "scalingo-db-backup",
+			expectedName: "db-backup",
+			expectedOK:   true,
+		},
+		"a credential helper": {
+			filename: "scalingo-credential-pass",
+		},
+		"the prefix only": {
+			filename: "scalingo-",
+		},
+		"another executable": {
+			filename: "scalingo",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			name, ok := pluginName(test.filename)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedName, name)
+		})
+	}
+}
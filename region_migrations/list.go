@@ -2,16 +2,15 @@ package region_migrations
 
 import (
 	"context"
-	"os"
 	"sort"
 	"time"
 
 	"github.com/fatih/color"
-	"github.com/olekukonko/tablewriter"
 	errgo "gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context, appId string) error {
@@ -24,7 +23,7 @@ func List(ctx context.Context, appId string) error {
 	if err != nil {
 		return errgo.Notef(err, "fail to list migrations")
 	}
-	if len(migrations) == 0 {
+	if len(migrations) == 0 && output.IsHuman() {
 		io.Status("No migration found for this app")
 		return nil
 	}
@@ -33,7 +32,7 @@ func List(ctx context.Context, appId string) error {
 		return migrations[i].StartedAt.Unix() > migrations[j].StartedAt.Unix()
 	})
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(migrations)
 	t.SetHeader([]string{"ID", "Destination", "Started At", "Finished At", "Status"})
 
 	for _, migration := range migrations {
@@ -50,7 +49,5 @@ func List(ctx context.Context, appId string) error {
 			formatMigrationStatus(migration.Status),
 		})
 	}
-	t.Render()
-
-	return nil
+	return t.Render()
 }
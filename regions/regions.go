@@ -2,12 +2,11 @@ package regions
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 )
 
 func List(ctx context.Context) error {
@@ -20,7 +19,7 @@ func List(ctx context.Context) error {
 		return errgo.Notef(err, "fail to list available regions")
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(regions)
 	t.SetColWidth(60)
 	t.SetHeader([]string{"Name", "Display", "API Endpoint"})
 
@@ -28,6 +27,5 @@ func List(ctx context.Context) error {
 		t.Append([]string{r.Name, r.DisplayName, r.API})
 	}
 
-	t.Render()
-	return nil
+	return t.Render()
 }
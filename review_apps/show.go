@@ -3,13 +3,12 @@ package review_apps
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/cli/utils"
 )
 
@@ -23,12 +22,12 @@ func Show(ctx context.Context, appID string) error {
 	if err != nil {
 		return errgo.Notef(err, "fail to get review apps for this app")
 	}
-	if len(reviewApps) == 0 {
+	if len(reviewApps) == 0 && output.IsHuman() {
 		io.Statusf("No review app for '%s' or specified app is not a parent app.\n", appID)
 		return nil
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(reviewApps)
 	t.SetHeader([]string{"App", "PR", "PR Branch", "Created At", "Status", "URL"})
 	for _, ra := range reviewApps {
 		date := ra.CreatedAt.Local().Format(utils.TimeFormat)
@@ -43,7 +42,5 @@ func Show(ctx context.Context, appID string) error {
 			date, fmt.Sprintf("%v", ra.LastDeployment.Status), app.URL,
 		})
 	}
-	t.Render()
-
-	return nil
+	return t.Render()
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/Scalingo/cli/cmd"
 	"github.com/Scalingo/cli/cmd/autocomplete"
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/cli/signals"
 	"github.com/Scalingo/cli/update"
 	"github.com/Scalingo/go-utils/logger"
@@ -32,6 +33,9 @@ func DefaultAction(c *cli.Context) error {
 	}
 
 	if !completeMode {
+		if cmd.RunPlugin(c) {
+			return nil
+		}
 		cmd.HelpCommand.Action(c)
 		cmd.ShowSuggestions(c)
 	} else {
@@ -116,7 +120,27 @@ func main() {
 		&cli.StringFlag{Name: "addon", Value: "<addon_id>", Usage: "ID of the current addon", EnvVars: []string{"SCALINGO_ADDON"}},
 		&cli.StringFlag{Name: "app", Aliases: []string{"a"}, Value: "<name>", Usage: "Name of the app", EnvVars: []string{"SCALINGO_APP"}},
 		&cli.StringFlag{Name: "remote", Aliases: []string{"r"}, Value: "scalingo", Usage: "Name of the remote"},
+		&cli.StringFlag{Name: "target", Usage: "Name of the app target of the .scalingo.yml project configuration file", EnvVars: []string{"SCALINGO_TARGET"}},
 		&cli.StringFlag{Name: "region", Value: "", Usage: "Name of the region to use"},
+		&cli.StringFlag{Name: "profile", Usage: "Name of the authentication profile to use", EnvVars: []string{"SCALINGO_PROFILE"}},
+		&cli.StringFlag{Name: "output", Value: "table", Usage: "Output format of the listing and information commands: table, json or yaml", EnvVars: []string{"SCALINGO_OUTPUT"}},
+		&cli.BoolFlag{Name: "json", Usage: "Display the output of the listing and information commands as JSON, shortcut for '--output json'"},
+		&cli.StringFlag{Name: "format", Usage: "Go template applied to each item displayed by the listing commands, e.g. '{{.Name}}\\t{{.Status}}'"},
+		&cli.StringFlag{Name: "columns", Usage: "Comma-separated list of the table columns to display, e.g. 'name,status'"},
+	}
+	app.Before = func(c *cli.Context) error {
+		if c.IsSet("profile") {
+			err := config.UseProfile(c.String("profile"))
+			if err != nil {
+				return err
+			}
+		}
+
+		format := c.String("output")
+		if c.Bool("json") {
+			format = string(output.FormatJSON)
+		}
+		return output.Configure(format, c.String("format"), c.String("columns"))
 	}
 	app.EnableBashCompletion = true
 	app.BashComplete = func(c *cli.Context) {
@@ -137,6 +161,7 @@ func main() {
 		}
 		app.Commands = append(app.Commands, command)
 	}
+	app.Commands = append(app.Commands, cmd.AliasCommands(app.Commands)...)
 
 	go signals.Handle()
 
@@ -147,6 +172,16 @@ func main() {
 		}
 	}
 
+	// The aliases are expanded before the dispatch of the command, os.Args is
+	// updated as it is also used by the autocompletion
+	args, err := cmd.ExpandAlias(app, os.Args)
+	if err == nil {
+		os.Args = args
+	} else if !bashComplete {
+		fmt.Println("Fail to run command:", err)
+		os.Exit(1)
+	}
+
 	if !bashComplete {
 		if len(os.Args) >= 2 && os.Args[1] == cmd.UpdateCommand.Name {
 			err := update.Check()
@@ -2,14 +2,13 @@ package scmintegrations
 
 import (
 	"context"
-	"os"
 	"strings"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -44,31 +43,36 @@ func ImportKeys(ctx context.Context, id string) error {
 			pluralKey = "s"
 		}
 
-		io.Statusf("0 key imported from %s.\n", scalingo.SCMTypeDisplay[integration.SCMType])
-		if alreadyImportedKeysLength == 0 {
-			io.Infof("No public key is available in your %s account\n", scalingo.SCMTypeDisplay[integration.SCMType])
-			return nil
-		}
-		io.Info()
+		if output.IsHuman() {
+			io.Statusf("0 key imported from %s.\n", scalingo.SCMTypeDisplay[integration.SCMType])
+			if alreadyImportedKeysLength == 0 {
+				io.Infof("No public key is available in your %s account\n", scalingo.SCMTypeDisplay[integration.SCMType])
+				return nil
+			}
+			io.Info()
 
-		io.Statusf(
-			"%d key%s have already been imported from %s:\n",
-			alreadyImportedKeysLength, pluralKey, scalingo.SCMTypeDisplay[integration.SCMType],
-		)
+			io.Statusf(
+				"%d key%s have already been imported from %s:\n",
+				alreadyImportedKeysLength, pluralKey, scalingo.SCMTypeDisplay[integration.SCMType],
+			)
+		}
 		keys = alreadyImportedKeys
 	} else {
 		keys = importedKeys
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(keys)
 	t.SetColWidth(60)
 	t.SetHeader([]string{"Name", "Content"})
 	for _, k := range keys {
 		t.Append([]string{k.Name, k.Content[0:20] + "..." + k.Content[len(k.Content)-30:]})
 	}
-	t.Render()
+	err = t.Render()
+	if err != nil {
+		return errgo.Mask(err)
+	}
 
-	if nbrKeys != 0 {
+	if nbrKeys != 0 && output.IsHuman() {
 		pluralKey := ""
 		if nbrKeys > 1 {
 			pluralKey = "s"
@@ -2,13 +2,12 @@ package scmintegrations
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	scalingo "github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -24,7 +23,7 @@ func List(ctx context.Context) error {
 	}
 
 	nbrIntegrations := len(integrations)
-	if nbrIntegrations == 0 {
+	if output.IsHuman() && nbrIntegrations == 0 {
 		io.Status("Your Scalingo account is not linked to any SCM integrations.")
 		return nil
 	}
@@ -34,14 +33,15 @@ func List(ctx context.Context) error {
 		pluralIntegration = "s"
 	}
 
-	io.Statusf("You already have %d SCM integration%s linked with your Scalingo account:\n", nbrIntegrations, pluralIntegration)
+	if output.IsHuman() {
+		io.Statusf("You already have %d SCM integration%s linked with your Scalingo account:\n", nbrIntegrations, pluralIntegration)
+	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	t := output.NewTable(integrations)
 	t.SetColWidth(60)
 	t.SetHeader([]string{"ID", "Type", "URL", "Username", "Email"})
 	for _, i := range integrations {
 		t.Append([]string{i.ID, scalingo.SCMTypeDisplay[i.SCMType], i.URL, i.Username, i.Email})
 	}
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -2,12 +2,12 @@ package stacks
 
 import (
 	"context"
-	"os"
 
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/errgo.v1"
 
 	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
 )
 
 func List(ctx context.Context, isWithDeprecatedFlag bool) error {
@@ -21,7 +21,8 @@ func List(ctx context.Context, isWithDeprecatedFlag bool) error {
 		return errgo.Notef(err, "fail to list available stacks")
 	}
 
-	t := tablewriter.NewWriter(os.Stdout)
+	displayedStacks := make([]scalingo.Stack, 0, len(stacks))
+	t := output.NewTable(&displayedStacks)
 
 	if isWithDeprecatedFlag {
 		t.SetHeader([]string{"ID", "Name", "Description", "Default?", "Deprecated?", "Deprecation date"})
@@ -48,10 +49,11 @@ func List(ctx context.Context, isWithDeprecatedFlag bool) error {
 			}
 
 			t.Append([]string{stack.ID, stack.Name, stack.Description, defaultText, deprecatedText, deprecationDate})
+			displayedStacks = append(displayedStacks, stack)
 		} else if !stack.IsDeprecated() {
 			t.Append([]string{stack.ID, stack.Name, stack.Description, defaultText})
+			displayedStacks = append(displayedStacks, stack)
 		}
 	}
-	t.Render()
-	return nil
+	return t.Render()
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 	"github.com/Scalingo/go-scalingo/v6"
 )
 
@@ -37,8 +38,9 @@ func Set(ctx context.Context, app string, stack string) error {
 		return errgo.Notef(err, "fail to set stack %v (%v)", stackToSet.Name, stackToSet.ID)
 	}
 
-	io.Statusf("Stack of %v has been set to %v (%v)\n", io.Bold(app), io.Bold(stackToSet.Name), stackToSet.ID)
-	io.Infof(io.Gray("Deployment cache of %v has been reset\n"), app)
+	w := output.Writer(ctx)
+	io.Fstatusf(w, "Stack of %v has been set to %v (%v)\n", io.Bold(app), io.Bold(stackToSet.Name), stackToSet.ID)
+	io.Finfof(w, io.Gray("Deployment cache of %v has been reset\n"), app)
 
 	return nil
 }
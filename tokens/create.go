@@ -0,0 +1,35 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func Create(ctx context.Context, name string) error {
+	c, err := config.ScalingoAuthClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	token, err := c.TokenCreate(ctx, scalingo.TokenCreateParams{Name: name})
+	if err != nil {
+		return errgo.Notef(err, "fail to create the API token")
+	}
+
+	if rendered, err := output.Data(token); rendered {
+		return errgo.Mask(err)
+	}
+
+	io.Statusf("API token '%s' has been created (ID: %s).\n", token.Name, token.ID)
+	io.Warning("Copy it now, its value will not be displayed again:")
+	fmt.Println()
+	fmt.Println(token.Token)
+	return nil
+}
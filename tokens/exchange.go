@@ -0,0 +1,35 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+)
+
+// Exchange prints a short-lived bearer token generated from the API token of
+// the current user. It is only printed on stdout to be used in scripts:
+//
+//	curl -H "Authorization: Bearer $(scalingo tokens-exchange)" ...
+func Exchange(ctx context.Context) error {
+	authenticator := &config.CliAuthenticator{}
+	_, token, err := authenticator.LoadAuth()
+	if err != nil {
+		return errgo.Notef(err, "fail to load credentials")
+	}
+
+	c, err := config.ScalingoUnauthenticatedAuthClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	bearerToken, err := c.TokenExchange(ctx, token.Token)
+	if err != nil {
+		return errgo.Notef(err, "fail to exchange the API token")
+	}
+
+	fmt.Println(bearerToken)
+	return nil
+}
@@ -0,0 +1,49 @@
+// Package tokens gathers the command handlers managing the API tokens of the
+// current user
+package tokens
+
+import (
+	"context"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/utils"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func List(ctx context.Context) error {
+	c, err := config.ScalingoAuthClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	tokens, err := c.TokensList(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to list API tokens")
+	}
+
+	return listTable(tokens).Render()
+}
+
+// listTable returns the table of the tokens. As with the tokens show command,
+// the value of the tokens is never displayed: it is only given once, at
+// creation time.
+func listTable(tokens scalingo.Tokens) *output.Table {
+	for _, token := range tokens {
+		token.Token = ""
+	}
+
+	t := output.NewTable(tokens)
+	t.SetHeader([]string{"ID", "Name", "Created At", "Last Used At"})
+
+	for _, token := range tokens {
+		lastUsedAt := "Never"
+		if !token.LastUsedAt.IsZero() {
+			lastUsedAt = token.LastUsedAt.Local().Format(utils.TimeFormat)
+		}
+		t.Append([]string{token.ID, token.Name, token.CreatedAt.Local().Format(utils.TimeFormat), lastUsedAt})
+	}
+	return t
+}
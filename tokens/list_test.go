@@ -0,0 +1,29 @@
+package tokens
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/go-scalingo/v6"
+)
+
+func TestListTable(t *testing.T) {
+	require.NoError(t, output.Configure("json", "", ""))
+	defer output.Configure("", "", "")
+
+	tokens := scalingo.Tokens{
+		{ID: "1", Name: "ci", CreatedAt: time.Date(2026, 10, 10, 14, 0, 0, 0, time.UTC), Token: "tk-us-s3cr3t"},
+	}
+	buffer := &bytes.Buffer{}
+	table := listTable(tokens)
+	table.SetWriter(buffer)
+	require.NoError(t, table.Render())
+
+	assert.NotContains(t, buffer.String(), "tk-us-s3cr3t")
+	assert.Contains(t, buffer.String(), `"name": "ci"`)
+}
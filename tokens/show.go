@@ -0,0 +1,43 @@
+package tokens
+
+import (
+	"context"
+	"strconv"
+
+	"gopkg.in/errgo.v1"
+
+	"github.com/Scalingo/cli/config"
+	"github.com/Scalingo/cli/output"
+	"github.com/Scalingo/cli/utils"
+)
+
+func Show(ctx context.Context, id string) error {
+	tokenID, err := strconv.Atoi(id)
+	if err != nil {
+		return errgo.Newf("invalid token ID '%s', it must be a number", id)
+	}
+
+	c, err := config.ScalingoAuthClient(ctx)
+	if err != nil {
+		return errgo.Notef(err, "fail to get Scalingo client")
+	}
+
+	token, err := c.TokenShow(ctx, tokenID)
+	if err != nil {
+		return errgo.Notef(err, "fail to get the API token")
+	}
+	// The value of the token is only given once, at creation time
+	token.Token = ""
+
+	lastUsedAt := "Never"
+	if !token.LastUsedAt.IsZero() {
+		lastUsedAt = token.LastUsedAt.Local().Format(utils.TimeFormat)
+	}
+
+	t := output.NewTable(token)
+	t.Append([]string{"ID", token.ID})
+	t.Append([]string{"Name", token.Name})
+	t.Append([]string{"Created At", token.CreatedAt.Local().Format(utils.TimeFormat)})
+	t.Append([]string{"Last Used At", lastUsedAt})
+	return t.Render()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Scalingo/cli/config"
 	"github.com/Scalingo/cli/io"
+	"github.com/Scalingo/cli/output"
 )
 
 func Self(ctx context.Context) error {
@@ -19,6 +20,10 @@ func Self(ctx context.Context) error {
 		return errgo.Notef(err, "fail to get user info")
 	}
 
+	if rendered, err := output.Data(user); rendered {
+		return errgo.Mask(err)
+	}
+
 	io.Statusf("You are logged in as %s (%s)\n", user.Username, user.Email)
 	return nil
 }
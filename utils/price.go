@@ -0,0 +1,8 @@
+package utils
+
+import "fmt"
+
+// FormatPrice formats a price given in cents, as returned by the API
+func FormatPrice(price int) string {
+	return fmt.Sprintf("%.2f €", float64(price)/100)
+}